@@ -0,0 +1,72 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// 输出配置时替换敏感字段
+const maskedValue = "******"
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "配置文件工具",
+	}
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "validate",
+			Short: "检查配置文件能否正确加载",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if _, err := loadConfig(); err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), "config is valid:", viper.ConfigFileUsed())
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "print",
+			Short: "输出合并后的配置，敏感字段会被隐藏",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				cfg, err := loadConfig()
+				if err != nil {
+					return err
+				}
+				if cfg.App != nil && cfg.App.JWT != nil {
+					app, jwt := *cfg.App, *cfg.App.JWT
+					jwt.Secret = maskedValue
+					app.JWT = &jwt
+					cfg.App = &app
+				}
+				if cfg.Database != nil {
+					database := *cfg.Database
+					if database.Password != "" {
+						database.Password = maskedValue
+					}
+					database.Replicas = slices.Clone(database.Replicas)
+					for i := range database.Replicas {
+						if database.Replicas[i].Password != "" {
+							database.Replicas[i].Password = maskedValue
+						}
+					}
+					cfg.Database = &database
+				}
+
+				out, err := yaml.Marshal(cfg)
+				if err != nil {
+					return err
+				}
+				_, err = cmd.OutOrStdout().Write(out)
+				return err
+			},
+		},
+	)
+	return cmd
+}
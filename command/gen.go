@@ -1,39 +1,51 @@
-package main
+package command
 
 import (
-	"telecommunications_repair_hub/config"
 	"telecommunications_repair_hub/models"
 	"telecommunications_repair_hub/models/query"
-	"telecommunications_repair_hub/pkg/db"
 
+	"github.com/spf13/cobra"
 	"gorm.io/gen"
 )
 
-func main() {
-	cfg := config.InitConfig()
+func newGenCommand() *cobra.Command {
+	var outPath string
+	cmd := &cobra.Command{
+		Use:   "gen",
+		Short: "生成 gorm/gen 查询代码",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			g := gen.NewGenerator(gen.Config{
+				OutPath:           outPath,
+				ModelPkgPath:      "../models",
+				Mode:              gen.WithoutContext | gen.WithDefaultQuery | gen.WithQueryInterface,
+				FieldNullable:     true,
+				FieldSignable:     true,
+				WithUnitTest:      true,
+				FieldCoverable:    true,
+				FieldWithIndexTag: true,
+				FieldWithTypeTag:  true,
+			})
 
-	g := gen.NewGenerator(gen.Config{
-		OutPath:           "./models/query",
-		ModelPkgPath:      "../models",
-		Mode:              gen.WithoutContext | gen.WithDefaultQuery | gen.WithQueryInterface,
-		FieldNullable:     true,
-		FieldSignable:     true,
-		WithUnitTest:      true,
-		FieldCoverable:    true,
-		FieldWithIndexTag: true,
-		FieldWithTypeTag:  true,
-	})
+			database, err := openDB()
+			if err != nil {
+				return err
+			}
+			defer database.Close()
 
-	db, err := db.New(cfg)
-	if err != nil {
-		panic(err)
-	}
-
-	query.SetDefault(db.DB)
+			query.SetDefault(database.DB)
 
-	g.UseDB(db.DB)
-	g.ApplyBasic(
-		&models.User{},
-	)
-	g.Execute()
+			g.UseDB(database.DB)
+			g.ApplyBasic(
+				&models.User{},
+				&models.RepairTicket{},
+				&models.Region{},
+				&models.UserRegion{},
+			)
+			g.Execute()
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&outPath, "out", "./models/query", "查询代码输出目录")
+	return cmd
 }
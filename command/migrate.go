@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"telecommunications_repair_hub/pkg/db"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newMigrateCommand() *cobra.Command {
+	migrate := &cobra.Command{
+		Use:   "migrate",
+		Short: "数据库迁移",
+	}
+	migrate.AddCommand(
+		newMigrateRunCommand("up", "执行未执行的迁移，--steps 为 0 时执行全部", (*db.DB).MigrateUp),
+		newMigrateRunCommand("down", "回滚最近执行的迁移，--steps 为 0 时回滚一个", (*db.DB).MigrateDown),
+		&cobra.Command{
+			Use:   "status",
+			Short: "查看迁移的执行状态",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				database, err := openDB()
+				if err != nil {
+					return err
+				}
+				defer database.Close()
+
+				statuses, err := database.MigrateStatus(cmd.Context())
+				if err != nil {
+					return err
+				}
+				for _, status := range statuses {
+					state, appliedAt := "pending", ""
+					if status.Applied {
+						state = "applied"
+						appliedAt = status.AppliedAt.Local().Format(time.DateTime)
+					}
+					if status.Missing {
+						state = "missing"
+					}
+					fmt.Fprintf(cmd.OutOrStdout(), "%-8s %04d_%-40s %s\n", state, status.Version, status.Name, appliedAt)
+				}
+				return nil
+			},
+		},
+	)
+	return migrate
+}
+
+type migrateFunc func(d *db.DB, ctx context.Context, opts db.MigrateOptions) ([]db.Migration, error)
+
+func newMigrateRunCommand(use, short string, run migrateFunc) *cobra.Command {
+	var opts db.MigrateOptions
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			database, err := openDB()
+			if err != nil {
+				return err
+			}
+			defer database.Close()
+
+			opts.Output = cmd.OutOrStdout()
+			executed, err := run(database, cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			if opts.DryRun {
+				return nil
+			}
+			for _, migration := range executed {
+				fmt.Fprintf(cmd.OutOrStdout(), "migrate %s: %s\n", use, migration)
+			}
+			if len(executed) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "migrate %s: nothing to do\n", use)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "只输出将要执行的 SQL，不修改数据库")
+	cmd.Flags().IntVar(&opts.Steps, "steps", 0, "执行的迁移数量")
+	return cmd
+}
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/pkg/db"
+
+	"github.com/spf13/cobra"
+)
+
+// exitError 携带进程退出码的错误
+type exitError struct {
+	code int
+}
+
+func (e *exitError) Error() string {
+	return fmt.Sprintf("exit code %d", e.code)
+}
+
+// 全部子命令共享的参数
+var options config.Options
+
+// NewRootCommand 创建根命令，子命令共享 --config 和 --env 参数
+func NewRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "telecommunications_repair_hub",
+		Short:         "电信报修服务",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.PersistentFlags().StringVarP(&options.File, "config", "c", "", "配置文件路径，默认在 ./config 和 . 目录中查找 config.yaml")
+	root.PersistentFlags().StringVarP(&options.Env, "env", "e", "", "运行环境，在配置文件之上合并 config.<env>.yaml")
+
+	root.AddCommand(
+		newServeCommand(),
+		newMigrateCommand(),
+		newGenCommand(),
+		newRoutesCommand(),
+		newConfigCommand(),
+		newUserCommand(),
+	)
+	return root
+}
+
+// Execute 执行命令并返回进程退出码
+func Execute() int {
+	err := NewRootCommand().Execute()
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	return 1
+}
+
+// loadConfig 按共享参数加载配置
+func loadConfig() (*config.Config, error) {
+	return config.Load(options)
+}
+
+// openDB 加载配置并连接数据库
+func openDB() (*db.DB, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return db.New(cfg)
+}
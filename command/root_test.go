@@ -0,0 +1,96 @@
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"telecommunications_repair_hub/models"
+	"telecommunications_repair_hub/pkg/auth"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Setenv("TELE_APP_JWT_SECRET", "telecommunications_repair_hub_test_secret")
+	os.Exit(m.Run())
+}
+
+func executeCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	root := NewRootCommand()
+	out := &bytes.Buffer{}
+	root.SetOut(out)
+	root.SetArgs(args)
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestConfigPrint_MasksPasswordAndMergesEnv(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+app:
+  port: "8080"
+database:
+  host: "localhost"
+  user: "postgres"
+  password: "db-password"
+  database: "tele_repair_hub"
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.test.yaml"), []byte(`
+app:
+  port: "9090"
+`), 0o644))
+
+	out, err := executeCommand(t, "config", "print", "--config", file, "--env", "test")
+	require.NoError(t, err)
+	assert.Contains(t, out, `port: "9090"`)
+	assert.Contains(t, out, "host: localhost")
+	assert.Contains(t, out, maskedValue)
+	assert.NotContains(t, out, "db-password")
+	assert.NotContains(t, out, os.Getenv("TELE_APP_JWT_SECRET"))
+}
+
+func TestConfigValidate_MissingFile(t *testing.T) {
+	_, err := executeCommand(t, "config", "validate", "--config", filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestUserSetRole_InvalidArgs(t *testing.T) {
+	_, err := executeCommand(t, "user", "set-role", "abc", "city_admin")
+	assert.ErrorContains(t, err, "invalid user id")
+
+	_, err = executeCommand(t, "user", "set-role", "1", "root")
+	assert.ErrorContains(t, err, "unknown user role")
+}
+
+func TestUserToken(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+database:
+  driver: "sqlite"
+  path: "`+filepath.Join(t.TempDir(), "tele.db")+`"
+`), 0o644))
+
+	_, err := executeCommand(t, "migrate", "up", "--config", file)
+	require.NoError(t, err)
+	_, err = executeCommand(t, "user", "create", "--config", file, "--username", "admin", "--phone", "13800138000", "--role", "city_admin")
+	require.NoError(t, err)
+
+	out, err := executeCommand(t, "user", "token", "1", "--config", file)
+	require.NoError(t, err)
+	claims, err := auth.ParseToken(strings.TrimSpace(out))
+	require.NoError(t, err)
+	assert.Equal(t, 1, claims.UserID)
+	assert.Equal(t, models.UserRoleCityAdmin, claims.Role)
+
+	_, err = executeCommand(t, "user", "token", "2", "--config", file)
+	assert.ErrorContains(t, err, "user not found")
+}
@@ -0,0 +1,23 @@
+package command
+
+import (
+	"telecommunications_repair_hub/http"
+
+	"github.com/spf13/cobra"
+)
+
+func newRoutesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "routes",
+		Short: "输出路由表，不启动服务",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			http.NewHttpServer(cfg, nil).PrintRoutes()
+			return nil
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package command
+
+import (
+	"telecommunications_repair_hub/server"
+
+	"github.com/spf13/cobra"
+)
+
+func newServeCommand() *cobra.Command {
+	var migrate bool
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "启动 HTTP 服务",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			code := server.NewTelecommunicationsServer(server.Options{Config: options, Migrate: migrate})
+			if code != server.ExitOK {
+				return &exitError{code: code}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&migrate, "migrate", false, "启动前执行未执行的数据库迁移")
+	return cmd
+}
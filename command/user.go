@@ -0,0 +1,175 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"telecommunications_repair_hub/models"
+	"telecommunications_repair_hub/models/query"
+	"telecommunications_repair_hub/pkg/auth"
+	"telecommunications_repair_hub/pkg/db"
+	"telecommunications_repair_hub/pkg/validation"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+func newUserCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "用户管理",
+	}
+	cmd.AddCommand(newUserCreateCommand(), newUserListCommand(), newUserSetRoleCommand(), newUserTokenCommand())
+	return cmd
+}
+
+func newUserCreateCommand() *cobra.Command {
+	var username, phone, role string
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "创建用户",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userRole, err := models.ParseUserRole(role)
+			if err != nil {
+				return err
+			}
+			if !validation.IsMobile(phone) {
+				return fmt.Errorf("invalid phone %q", phone)
+			}
+
+			return withQuery(func() error {
+				user := &models.User{Username: username, Phone: phone, Role: userRole}
+				if err := query.User.Create(user); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "user %d created\n", user.ID)
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&username, "username", "", "用户名")
+	cmd.Flags().StringVar(&phone, "phone", "", "手机号")
+	cmd.Flags().StringVar(&role, "role", "end_user", "角色，可使用角色名称或 end_user、area_manager、city_admin")
+	cmd.MarkFlagRequired("username")
+	cmd.MarkFlagRequired("phone")
+	return cmd
+}
+
+func newUserListCommand() *cobra.Command {
+	var role string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "列出用户",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withQuery(func() error {
+				do := query.User.Order(query.User.ID)
+				if role != "" {
+					userRole, err := models.ParseUserRole(role)
+					if err != nil {
+						return err
+					}
+					do = do.Where(query.User.Role.Eq(&userRole))
+				}
+				users, err := do.Find()
+				if err != nil {
+					return err
+				}
+
+				t := table.NewWriter()
+				t.SetOutputMirror(cmd.OutOrStdout())
+				t.AppendHeader(table.Row{"ID", "用户名", "手机号", "角色", "创建时间"})
+				for _, user := range users {
+					t.AppendRow(table.Row{user.ID, user.Username, user.Phone, user.Role, user.CreatedAt.Format(time.DateTime)})
+				}
+				t.Render()
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&role, "role", "", "只列出指定角色的用户")
+	return cmd
+}
+
+func newUserSetRoleCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-role <id> <role>",
+		Short: "修改用户角色",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid user id %q", args[0])
+			}
+			userRole, err := models.ParseUserRole(args[1])
+			if err != nil {
+				return err
+			}
+
+			return withQuery(func() error {
+				info, err := query.User.Where(query.User.ID.Eq(id)).Update(query.User.Role, &userRole)
+				if err != nil {
+					return err
+				}
+				if info.RowsAffected == 0 {
+					return errors.New("user not found")
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "user %d role set to %s\n", id, userRole)
+				return nil
+			})
+		},
+	}
+}
+
+// newUserTokenCommand 为用户签发访问 token，使用配置 app.jwt.secret 签名，有效期与服务签发的 token 一致
+func newUserTokenCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "token <id>",
+		Short: "为用户签发访问 token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid user id %q", args[0])
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			auth.SetSecret(cfg.GetJWTConfig().Secret)
+
+			return withQuery(func() error {
+				user, err := query.User.Where(query.User.ID.Eq(id)).First()
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return errors.New("user not found")
+				}
+				if err != nil {
+					return err
+				}
+				token, err := auth.IssueToken(user)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), token)
+				return nil
+			})
+		},
+	}
+}
+
+// withQuery 连接数据库并设置 query 默认连接后执行 fn
+func withQuery(fn func() error) error {
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer func(database *db.DB) {
+		database.Close()
+	}(database)
+
+	query.SetDefault(database.DB)
+	return fn()
+}
@@ -1,53 +1,234 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	App      *AppConfig      `yaml:"app"`
-	Database *DatabaseConfig `yaml:"database"`
+	App      *AppConfig      `yaml:"app" validate:"required"`
+	Database *DatabaseConfig `yaml:"database" validate:"required"`
+	// 功能开关，名称不区分大小写，支持热更新
+	Features map[string]bool `yaml:"features"`
 }
 
 type AppConfig struct {
-	Port      string        `yaml:"port"`
-	Host      string        `yaml:"host"`
-	LogLevel  string        `yaml:"logLevel"`
-	LogOutput string        `yaml:"logOutput"`
-	Logger    *LoggerConfig `yaml:"logger"`
+	Port      string           `yaml:"port" default:"8080" validate:"port"`
+	Host      string           `yaml:"host" default:"0.0.0.0"`
+	LogLevel  string           `yaml:"logLevel" default:"info" validate:"oneof=debug info warn error"`
+	LogOutput string           `yaml:"logOutput" default:"stdout" validate:"oneof=stdout file mixed"`
+	Logger    *LoggerConfig    `yaml:"logger"`
+	Response  *ResponseConfig  `yaml:"response"`
+	OpenAPI   *OpenAPIConfig   `yaml:"openapi"`
+	Upload    *UploadConfig    `yaml:"upload" validate:"required"`
+	Shutdown  *ShutdownConfig  `yaml:"shutdown"`
+	Health    *HealthConfig    `yaml:"health"`
+	RateLimit *RateLimitConfig `yaml:"rateLimit"`
+	CORS      *CORSConfig      `yaml:"cors"`
+	JWT       *JWTConfig       `yaml:"jwt" validate:"required"`
+}
+
+// JWTConfig 访问 token 配置，运维人员通过 user token <id> 命令为用户签发 token
+type JWTConfig struct {
+	// HMAC 签名密钥，至少 32 字节，通过环境变量 TELE_APP_JWT_SECRET 或 TELE_APP_JWT_SECRET_FILE 设置
+	Secret string `yaml:"secret" default:"" validate:"required,min=32"`
+}
+
+// RateLimitConfig 下载限速，支持热更新
+type RateLimitConfig struct {
+	// 每秒传输量，单位 MB
+	Limit int `yaml:"limit" default:"10" validate:"min=1"`
+	// 突发传输量，单位 MB
+	Burst int `yaml:"burst" default:"10" validate:"min=1"`
+}
+
+// CORSConfig 跨域配置，支持热更新
+type CORSConfig struct {
+	// 允许的来源，* 表示允许全部来源
+	AllowOrigins []string `yaml:"allowOrigins" default:"*" validate:"min=1"`
+}
+
+type HealthConfig struct {
+	// 单个检查的超时时间
+	Timeout time.Duration `yaml:"timeout" default:"2s" validate:"gt=0s"`
+	// 检查可用空间的目录，通常为日志输出目录
+	DiskPath string `yaml:"diskPath" default:"."`
+	// 最小可用空间，单位 MB，为 0 时不检查磁盘空间
+	DiskMinFree int `yaml:"diskMinFree" default:"0" validate:"min=0"`
+	// 存活检查允许的最大 goroutine 数量，超过时 /livez 返回失败，为 0 时不检查
+	MaxGoroutines int `yaml:"maxGoroutines" default:"10000" validate:"min=0"`
+}
+
+type ShutdownConfig struct {
+	// 收到退出信号后，readiness 置为 false 并等待负载均衡摘除流量的时间
+	DrainPeriod time.Duration `yaml:"drainPeriod" default:"5s" validate:"min=0s"`
+	// 等待处理中的请求完成和执行关闭钩子的超时时间
+	Timeout time.Duration `yaml:"timeout" default:"30s" validate:"gt=0s"`
+}
+
+type UploadConfig struct {
+	// 上传文件保存目录
+	Dir string `yaml:"dir" default:"uploads" validate:"required"`
+	// 单个文件大小上限，单位 MB，为 0 时不限制
+	MaxSize int `yaml:"maxSize" default:"10" validate:"min=0"`
+}
+
+type ResponseConfig struct {
+	// 错误响应的 HTTP 状态码模式：status 返回对应状态码，legacy 始终返回 200
+	Mode string `yaml:"mode" default:"status" validate:"oneof=status legacy"`
+	// 错误响应格式：envelope 统一响应结构，problem 为 RFC 7807 application/problem+json
+	Format string `yaml:"format" default:"envelope" validate:"oneof=envelope problem"`
 }
 
 type LoggerConfig struct {
-	Rotation      string `yaml:"rotation"`
-	RotationSize  int    `yaml:"rotationSize"`
-	RotationCount int    `yaml:"rotationCount"`
-	RotationTime  string `yaml:"rotationTime"`
+	Rotation      string `yaml:"rotation" default:"1h" validate:"duration"`
+	RotationSize  int    `yaml:"rotationSize" default:"1024" validate:"min=1"`
+	RotationCount int    `yaml:"rotationCount" default:"3" validate:"min=0"`
+	RotationTime  string `yaml:"rotationTime" default:"1h" validate:"duration"`
 }
 
 type DatabaseConfig struct {
+	// 数据库类型：postgres, sqlite, mysql
+	Driver string `yaml:"driver" default:"postgres" validate:"oneof=postgres sqlite mysql"`
+	Host   string `yaml:"host" validate:"required_unless=Driver sqlite"`
+	// 为 0 时使用数据库的默认端口
+	Port     int    `yaml:"port" validate:"omitempty,min=1,max=65535"`
+	User     string `yaml:"user" validate:"required_unless=Driver sqlite"`
+	Password string `yaml:"password"`
+	Database string `yaml:"database" validate:"required_unless=Driver sqlite"`
+	// sqlite 数据库文件路径，:memory: 为内存数据库
+	Path string `yaml:"path" validate:"required_if=Driver sqlite"`
+	// 连接使用的时区
+	TimeZone string `yaml:"timeZone" default:"Asia/Shanghai" validate:"timezone"`
+	// postgres 的 sslmode，mysql 不为 disable 时启用 TLS
+	SSLMode string `yaml:"sslMode" default:"disable" validate:"oneof=disable allow prefer require verify-ca verify-full"`
+	// CA 证书路径
+	SSLRootCert string `yaml:"sslRootCert"`
+	// 客户端证书和私钥路径，双向认证时使用
+	SSLCert string `yaml:"sslCert"`
+	SSLKey  string `yaml:"sslKey"`
+
+	// 连接池最大连接数，为 0 时不限制，副本使用相同的连接池配置
+	MaxOpenConns int `yaml:"maxOpenConns" default:"20" validate:"min=0"`
+	// 最大空闲连接数，超过 maxOpenConns 时按 maxOpenConns 处理
+	MaxIdleConns int `yaml:"maxIdleConns" default:"5" validate:"min=0"`
+	// 连接的最长使用时间和最长空闲时间，为 0 时不回收，sqlite 内存数据库始终不回收
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" default:"30m" validate:"min=0s"`
+	ConnMaxIdleTime time.Duration `yaml:"connMaxIdleTime" default:"5m" validate:"min=0s"`
+	// 单条语句的执行超时时间，为 0 时不限制，postgres 使用 statement_timeout，mysql 使用 max_execution_time 只限制查询
+	StatementTimeout time.Duration `yaml:"statementTimeout" default:"0s" validate:"min=0s"`
+	// SQL 日志，支持热更新
+	Log *DatabaseLogConfig `yaml:"log"`
+
+	// 只读副本，未配置时读写都使用主库
+	Replicas []ReplicaConfig `yaml:"replicas" validate:"unique=Name,dive"`
+	// 副本负载均衡策略：random, round_robin, least_conn
+	Policy string `yaml:"policy" default:"random" validate:"oneof=random round_robin least_conn"`
+	// 副本健康检查间隔，检查失败的副本不再分配查询，恢复后重新加入
+	HealthCheckInterval time.Duration `yaml:"healthCheckInterval" default:"10s" validate:"gt=0s"`
+	// 按表配置读取使用的副本，未配置的表使用全部副本
+	Tables []TableResolverConfig `yaml:"tables" validate:"dive"`
+}
+
+// DatabaseLogConfig SQL 日志配置
+type DatabaseLogConfig struct {
+	// 慢查询阈值，执行时间超过阈值的语句以 WARN 级别记录，为 0 时不记录慢查询
+	SlowThreshold time.Duration `yaml:"slowThreshold" default:"200ms" validate:"min=0s"`
+	// 日志中隐藏参数值的列名
+	RedactColumns []string `yaml:"redactColumns" default:"phone,password"`
+	// 调试模式，记录每个请求执行的全部 SQL，请求结束时输出
+	Debug bool `yaml:"debug" default:"false"`
+}
+
+// ReplicaConfig 只读副本，未设置的字段使用主库的配置
+type ReplicaConfig struct {
+	// 副本名称，用于按表配置和监控指标
+	Name     string `yaml:"name" validate:"required,ne=primary"`
 	Host     string `yaml:"host"`
-	Port     int    `yaml:"port"`
+	Port     int    `yaml:"port" validate:"omitempty,min=1,max=65535"`
 	User     string `yaml:"user"`
 	Password string `yaml:"password"`
 	Database string `yaml:"database"`
+	// sqlite 数据库文件路径
+	Path string `yaml:"path"`
+}
+
+// TableResolverConfig 指定表的读取策略
+type TableResolverConfig struct {
+	// 表名，如 tele_repair_ticket
+	Names []string `yaml:"names" validate:"min=1,dive,required"`
+	// 读取使用的副本名称，为空时读写都使用主库，适用于需要读取最新数据的表
+	Replicas []string `yaml:"replicas"`
+	// 为空时使用 database.policy
+	Policy string `yaml:"policy" validate:"omitempty,oneof=random round_robin least_conn"`
+}
+
+// Options 加载配置的选项，命令行的 --config 和 --env 参数
+type Options struct {
+	// 配置文件路径，为空时在 ./config 和 . 目录中查找 config.yaml
+	File string
+	// 运行环境，为空时使用环境变量 TELE_ENV，不为空时在 config.yaml 之上合并同目录下的 config.<env>.yaml
+	Env string
+}
+
+func InitConfig() (*Config, error) {
+	return Load(Options{})
+}
+
+// Load 按选项加载配置并校验，优先级从高到低：
+// TELE_ 前缀的环境变量（或 _FILE 指定的文件） > config.<env>.yaml > config.yaml > default 标签
+func Load(opts Options) (*Config, error) {
+	config, _, err := load(viper.GetViper(), opts)
+	return config, err
 }
 
-func InitConfig() *Config {
-	viper.AddConfigPath("config")
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(".")
-	err := viper.ReadInConfig()
-	if err != nil {
-		panic(err)
+// load 使用指定的 viper 实例加载配置，同时返回读取的配置文件
+func load(v *viper.Viper, opts Options) (*Config, []string, error) {
+	if opts.File != "" {
+		v.SetConfigFile(opts.File)
+	} else {
+		v.AddConfigPath("config")
+		v.SetConfigName("config")
+		v.SetConfigType("yaml")
+		v.AddConfigPath(".")
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to read config")
 	}
+	files := []string{v.ConfigFileUsed()}
 
-	config := &Config{}
+	env := opts.Env
+	if env == "" {
+		env = os.Getenv(envProfile)
+	}
+	if env != "" {
+		file := v.ConfigFileUsed()
+		ext := filepath.Ext(file)
+		v.SetConfigFile(strings.TrimSuffix(file, ext) + "." + env + ext)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, nil, errors.WithMessagef(err, "failed to merge config for env %s", env)
+		}
+		files = append(files, v.ConfigFileUsed())
+	}
+
+	setDefaults(v)
+	if err := bindEnv(v); err != nil {
+		return nil, nil, err
+	}
 
-	if err := viper.Unmarshal(config); err != nil {
-		panic(err)
+	config := &Config{}
+	if err := v.Unmarshal(config); err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to decode config")
 	}
-	return config
+	if err := config.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return config, files, nil
 }
 
 func (c *Config) GetAppConfig() *AppConfig {
@@ -58,6 +239,56 @@ func (c *Config) GetLoggerConfig() *LoggerConfig {
 	return c.App.Logger
 }
 
+type OpenAPIConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// OpenAPI 文档路径
+	Path string `yaml:"path" default:"/openapi.json" validate:"startswith=/"`
+	// 文档页面路径
+	UIPath string `yaml:"uiPath" default:"/docs" validate:"startswith=/"`
+	// 文档页面：swagger, redoc
+	UI      string `yaml:"ui" default:"swagger" validate:"oneof=swagger redoc"`
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+func (c *Config) GetOpenAPIConfig() *OpenAPIConfig {
+	return c.App.OpenAPI
+}
+
+func (c *Config) GetResponseConfig() *ResponseConfig {
+	return c.App.Response
+}
+
+func (c *Config) GetHealthConfig() *HealthConfig {
+	return c.App.Health
+}
+
+func (c *Config) GetShutdownConfig() *ShutdownConfig {
+	return c.App.Shutdown
+}
+
+func (c *Config) GetUploadConfig() *UploadConfig {
+	return c.App.Upload
+}
+
 func (c *Config) GetDatabaseConfig() *DatabaseConfig {
 	return c.Database
 }
+
+func (c *Config) GetRateLimitConfig() *RateLimitConfig {
+	return c.App.RateLimit
+}
+
+func (c *Config) GetCORSConfig() *CORSConfig {
+	return c.App.CORS
+}
+
+func (c *Config) GetJWTConfig() *JWTConfig {
+	return c.App.JWT
+}
+
+// FeatureEnabled 功能开关是否开启，未配置的功能视为关闭
+func (c *Config) FeatureEnabled(name string) bool {
+	// viper 读取的配置项名称均为小写
+	return c.Features[strings.ToLower(name)]
+}
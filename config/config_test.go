@@ -1,14 +1,24 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // writeTempConfig writes a config.yaml into dir with the provided content.
+// 测试使用的 token 签名密钥，通过环境变量设置，测试配置文件中不需要填写
+const testJWTSecret = "telecommunications_repair_hub_test_secret"
+
+func TestMain(m *testing.M) {
+	os.Setenv("TELE_APP_JWT_SECRET", testJWTSecret)
+	os.Exit(m.Run())
+}
+
 func writeTempConfig(t *testing.T, dir string, content string) {
 	t.Helper()
 	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0o644); err != nil {
@@ -59,7 +69,10 @@ database:
 	withChdir(t, tempDir, func() {
 		viper.Reset()
 
-		cfg := InitConfig()
+		cfg, err := InitConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 
 		// 测试 Config 结构体
 		if cfg == nil {
@@ -95,23 +108,19 @@ database:
 	})
 }
 
-func TestInitConfig_PanicsWithoutConfig(t *testing.T) {
+func TestInitConfig_ErrorWithoutConfig(t *testing.T) {
 	tempDir := t.TempDir()
 
 	withChdir(t, tempDir, func() {
 		viper.Reset()
 
-		defer func() {
-			if r := recover(); r == nil {
-				t.Fatalf("expected panic when config.yaml is missing")
-			}
-		}()
-
-		_ = InitConfig()
+		if _, err := InitConfig(); err == nil {
+			t.Fatalf("expected error when config.yaml is missing")
+		}
 	})
 }
 
-func TestInitConfig_PanicsWithInvalidConfig(t *testing.T) {
+func TestInitConfig_ErrorWithInvalidConfig(t *testing.T) {
 	tempDir := t.TempDir()
 
 	// 无效的 YAML 格式
@@ -126,13 +135,9 @@ app:
 	withChdir(t, tempDir, func() {
 		viper.Reset()
 
-		defer func() {
-			if r := recover(); r == nil {
-				t.Fatalf("expected panic with invalid YAML")
-			}
-		}()
-
-		_ = InitConfig()
+		if _, err := InitConfig(); err == nil {
+			t.Fatalf("expected error with invalid YAML")
+		}
 	})
 }
 
@@ -275,3 +280,279 @@ func TestDatabaseConfig_Fields(t *testing.T) {
 		t.Errorf("expected Database=testdb, got %q", db.Database)
 	}
 }
+
+func TestLoad_EnvOverridesNestedKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTempConfig(t, tempDir, `
+app:
+  port: "8080"
+  shutdown:
+    timeout: "30s"
+database:
+  host: "localhost"
+  port: 5432
+  user: "postgres"
+  database: "tele_repair_hub"
+`)
+	t.Setenv("TELE_APP_PORT", "9090")
+	t.Setenv("TELE_APP_SHUTDOWN_TIMEOUT", "10s")
+	t.Setenv("TELE_DATABASE_PORT", "5433")
+	// 配置文件中不存在的配置项
+	t.Setenv("TELE_DATABASE_PASSWORD", "secret")
+
+	viper.Reset()
+	cfg, err := Load(Options{File: filepath.Join(tempDir, "config.yaml")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.App.Port != "9090" {
+		t.Errorf("expected port=9090, got %q", cfg.App.Port)
+	}
+	if cfg.App.Shutdown == nil || cfg.App.Shutdown.Timeout != 10*time.Second {
+		t.Errorf("expected shutdown timeout=10s, got %+v", cfg.App.Shutdown)
+	}
+	if cfg.Database.Port != 5433 {
+		t.Errorf("expected database port=5433, got %d", cfg.Database.Port)
+	}
+	if cfg.Database.Password != "secret" {
+		t.Errorf("expected database password from env, got %q", cfg.Database.Password)
+	}
+	if cfg.Database.Host != "localhost" {
+		t.Errorf("expected database host=localhost, got %q", cfg.Database.Host)
+	}
+}
+
+func TestLoad_SecretFile(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTempConfig(t, tempDir, `
+database:
+  host: "localhost"
+  user: "postgres"
+  password: "from-config"
+  database: "tele_repair_hub"
+`)
+	secretFile := filepath.Join(tempDir, "db_password")
+	if err := os.WriteFile(secretFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+	t.Setenv("TELE_DATABASE_PASSWORD_FILE", secretFile)
+
+	viper.Reset()
+	cfg, err := Load(Options{File: filepath.Join(tempDir, "config.yaml")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Database.Password != "from-file" {
+		t.Errorf("expected password from file, got %q", cfg.Database.Password)
+	}
+
+	// 同时设置时以环境变量为准
+	t.Setenv("TELE_DATABASE_PASSWORD", "from-env")
+	viper.Reset()
+	cfg, err = Load(Options{File: filepath.Join(tempDir, "config.yaml")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Database.Password != "from-env" {
+		t.Errorf("expected password from env, got %q", cfg.Database.Password)
+	}
+}
+
+func TestLoad_EnvProfile(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTempConfig(t, tempDir, `
+app:
+  port: "8080"
+  host: "0.0.0.0"
+database:
+  host: "localhost"
+  user: "postgres"
+  database: "tele_repair_hub"
+`)
+	if err := os.WriteFile(filepath.Join(tempDir, "config.prod.yaml"), []byte(`
+app:
+  port: "80"
+`), 0o644); err != nil {
+		t.Fatalf("failed to write profile config: %v", err)
+	}
+	t.Setenv("TELE_ENV", "prod")
+
+	withChdir(t, tempDir, func() {
+		viper.Reset()
+		cfg, err := InitConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.App.Port != "80" {
+			t.Errorf("expected port=80 from profile, got %q", cfg.App.Port)
+		}
+		if cfg.App.Host != "0.0.0.0" {
+			t.Errorf("expected host=0.0.0.0 from base config, got %q", cfg.App.Host)
+		}
+	})
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTempConfig(t, tempDir, `
+database:
+  host: "localhost"
+  user: "postgres"
+  database: "tele_repair_hub"
+`)
+
+	viper.Reset()
+	cfg, err := Load(Options{File: filepath.Join(tempDir, "config.yaml")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.App.Port != "8080" || cfg.App.LogLevel != "info" || cfg.App.LogOutput != "stdout" {
+		t.Errorf("unexpected app defaults: %+v", cfg.App)
+	}
+	if cfg.App.Logger == nil || cfg.App.Logger.Rotation != "1h" || cfg.App.Logger.RotationSize != 1024 {
+		t.Errorf("unexpected logger defaults: %+v", cfg.App.Logger)
+	}
+	if cfg.App.Shutdown == nil || cfg.App.Shutdown.Timeout != 30*time.Second {
+		t.Errorf("unexpected shutdown defaults: %+v", cfg.App.Shutdown)
+	}
+	if cfg.Database.Driver != "postgres" || cfg.Database.TimeZone != "Asia/Shanghai" || cfg.Database.SSLMode != "disable" {
+		t.Errorf("unexpected database defaults: %+v", cfg.Database)
+	}
+	if cfg.Database.MaxOpenConns != 20 || cfg.Database.MaxIdleConns != 5 || cfg.Database.ConnMaxLifetime != 30*time.Minute || cfg.Database.StatementTimeout != 0 {
+		t.Errorf("unexpected pool defaults: %+v", cfg.Database)
+	}
+	if log := cfg.Database.Log; log == nil || log.SlowThreshold != 200*time.Millisecond || len(log.RedactColumns) != 2 || log.Debug {
+		t.Errorf("unexpected database log defaults: %+v", cfg.Database.Log)
+	}
+}
+
+func TestLoad_ReportsAllProblems(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTempConfig(t, tempDir, `
+app:
+  port: "70000"
+  logLevel: "verbose"
+  logger:
+    rotation: "hourly"
+database:
+  host: "localhost"
+`)
+
+	viper.Reset()
+	_, err := Load(Options{File: filepath.Join(tempDir, "config.yaml")})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+
+	keys := map[string]bool{}
+	for _, problem := range validationErr.Problems {
+		keys[problem.Key] = true
+	}
+	for _, key := range []string{"app.port", "app.logLevel", "app.logger.rotation", "database.user", "database.database"} {
+		if !keys[key] {
+			t.Errorf("expected problem for %s, got %v", key, err)
+		}
+	}
+}
+
+func TestLoad_SQLiteDoesNotRequireServerFields(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTempConfig(t, tempDir, `
+database:
+  driver: "sqlite"
+  path: ":memory:"
+`)
+
+	viper.Reset()
+	cfg, err := Load(Options{File: filepath.Join(tempDir, "config.yaml")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Database.Path != ":memory:" {
+		t.Errorf("expected sqlite path, got %q", cfg.Database.Path)
+	}
+
+	writeTempConfig(t, tempDir, `
+database:
+  driver: "sqlite"
+`)
+	viper.Reset()
+	_, err = Load(Options{File: filepath.Join(tempDir, "config.yaml")})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Problems) != 1 || validationErr.Problems[0].Key != "database.path" {
+		t.Errorf("expected only database.path problem, got %v", err)
+	}
+}
+
+func TestLoad_DatabaseReplicas(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTempConfig(t, tempDir, `
+database:
+  driver: "sqlite"
+  path: "primary.db"
+  replicas:
+    - name: "replica1"
+      path: "replica1.db"
+  tables:
+    - names: ["tele_user"]
+    - names: ["tele_repair_ticket"]
+      replicas: ["replica1", "replica2"]
+`)
+
+	viper.Reset()
+	_, err := Load(Options{File: filepath.Join(tempDir, "config.yaml")})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Problems) != 1 {
+		t.Fatalf("expected one problem, got %v", err)
+	}
+	if key := validationErr.Problems[0].Key; key != "database.tables[1].replicas[1]" {
+		t.Errorf("expected unknown replica problem, got %s", key)
+	}
+
+	writeTempConfig(t, tempDir, `
+database:
+  driver: "sqlite"
+  path: "primary.db"
+  replicas:
+    - name: "replica1"
+      path: "replica1.db"
+`)
+	viper.Reset()
+	cfg, err := Load(Options{File: filepath.Join(tempDir, "config.yaml")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Database.Replicas) != 1 || cfg.Database.Replicas[0].Path != "replica1.db" {
+		t.Errorf("expected replica1, got %+v", cfg.Database.Replicas)
+	}
+	if cfg.Database.Policy != "random" || cfg.Database.HealthCheckInterval != 10*time.Second {
+		t.Errorf("expected resolver defaults, got %s %s", cfg.Database.Policy, cfg.Database.HealthCheckInterval)
+	}
+}
+
+func TestLoad_RequiresJWTSecret(t *testing.T) {
+	t.Setenv("TELE_APP_JWT_SECRET", "")
+	os.Unsetenv("TELE_APP_JWT_SECRET")
+	tempDir := t.TempDir()
+	writeTempConfig(t, tempDir, `
+database:
+  driver: "sqlite"
+  path: ":memory:"
+`)
+
+	viper.Reset()
+	_, err := Load(Options{File: filepath.Join(tempDir, "config.yaml")})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Problems) != 1 || validationErr.Problems[0].Key != "app.jwt.secret" {
+		t.Fatalf("expected only app.jwt.secret problem, got %v", err)
+	}
+
+	t.Setenv("TELE_APP_JWT_SECRET", "short")
+	viper.Reset()
+	_, err = Load(Options{File: filepath.Join(tempDir, "config.yaml")})
+	if !errors.As(err, &validationErr) || validationErr.Problems[0].Key != "app.jwt.secret" {
+		t.Fatalf("expected short secret rejected, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// 环境变量前缀，配置项 database.password 对应 TELE_DATABASE_PASSWORD
+const envPrefix = "TELE"
+
+// 指定运行环境的环境变量，未传入 --env 时使用
+const envProfile = envPrefix + "_ENV"
+
+// 敏感配置可以通过 <环境变量>_FILE 指定从文件读取，如 TELE_DATABASE_PASSWORD_FILE=/run/secrets/db_password
+const envFileSuffix = "_FILE"
+
+var envKeyReplacer = strings.NewReplacer(".", "_")
+
+// bindEnv 为 Config 的全部配置项绑定环境变量，配置文件中不存在的配置项也可以通过环境变量设置
+func bindEnv(v *viper.Viper) error {
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(envKeyReplacer)
+
+	for _, field := range configFields(reflect.TypeFor[Config](), "") {
+		key := field.Key
+		if err := v.BindEnv(key); err != nil {
+			return errors.WithMessagef(err, "failed to bind env for %s", key)
+		}
+
+		name := envName(key)
+		file, ok := os.LookupEnv(name + envFileSuffix)
+		if !ok {
+			continue
+		}
+		// 同时设置时以环境变量为准
+		if _, ok := os.LookupEnv(name); ok {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to read %s%s", name, envFileSuffix)
+		}
+		v.Set(key, strings.TrimRight(string(content), "\r\n"))
+	}
+	return nil
+}
+
+// envName 配置项对应的环境变量名
+func envName(key string) string {
+	return envPrefix + "_" + strings.ToUpper(envKeyReplacer.Replace(key))
+}
+
+// configField 配置项及其对应的结构体字段
+type configField struct {
+	// 以 . 连接的配置项路径，如 app.logger.rotation
+	Key   string
+	Field reflect.StructField
+}
+
+// configFields 按 yaml 标签返回结构体的全部配置项，嵌套结构体以 . 连接
+func configFields(t reflect.Type, prefix string) []configField {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var fields []configField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := yamlName(field)
+		if name == "" {
+			continue
+		}
+		key := prefix + name
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			fields = append(fields, configFields(fieldType, key+".")...)
+			continue
+		}
+		fields = append(fields, configField{Key: key, Field: field})
+	}
+	return fields
+}
+
+// yamlName 字段的 yaml 名称，忽略的字段返回空字符串
+func yamlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
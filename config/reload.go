@@ -0,0 +1,257 @@
+package config
+
+import (
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+// 支持热更新的配置项，其他配置项修改后需要重启服务才能生效
+var reloadableKeys = []string{
+	"app.logLevel",
+	"app.rateLimit",
+	"app.cors",
+	"database.log",
+	"features",
+}
+
+// 配置文件变化后等待的时间，编辑器保存文件时可能连续触发多个事件
+const reloadDebounce = 200 * time.Millisecond
+
+// ReloadCounter 配置热更新次数，result 为 applied、rejected 或 unchanged
+var ReloadCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "config_reloads_total",
+		Help: "Total number of configuration reloads",
+	},
+	[]string{"result"},
+)
+
+// Subscriber 配置热更新后收到新配置，不应阻塞
+type Subscriber func(cfg *Config)
+
+type namedSubscriber struct {
+	name string
+	fn   Subscriber
+}
+
+// Reloader 监听配置文件变化，校验通过后只更新支持热更新的配置项并通知订阅者
+type Reloader struct {
+	options Options
+	current atomic.Pointer[Config]
+	// 串行执行 Reload
+	reloadMu sync.Mutex
+
+	mu          sync.Mutex
+	subscribers []namedSubscriber
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+func NewReloader(opts Options, cfg *Config) *Reloader {
+	r := &Reloader{options: opts}
+	r.current.Store(cfg)
+	return r
+}
+
+// Current 当前生效的配置
+func (r *Reloader) Current() *Config {
+	return r.current.Load()
+}
+
+// Subscribe 订阅配置热更新，按订阅顺序通知
+func (r *Reloader) Subscribe(name string, fn Subscriber) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers = append(r.subscribers, namedSubscriber{name: name, fn: fn})
+}
+
+// Reload 重新加载配置，校验失败时保持当前配置不变并返回错误
+func (r *Reloader) Reload() error {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	next, _, err := load(viper.New(), r.options)
+	if err != nil {
+		ReloadCounter.WithLabelValues("rejected").Inc()
+		slog.Error("[Config] Reload rejected", "Error", err)
+		return err
+	}
+
+	current := r.Current()
+	var applied, restartRequired []string
+	for _, key := range changedKeys(reflect.ValueOf(current), reflect.ValueOf(next), "") {
+		if isReloadable(key) {
+			applied = append(applied, key)
+		} else {
+			restartRequired = append(restartRequired, key)
+		}
+	}
+	if len(restartRequired) > 0 {
+		slog.Warn("[Config] Changes require restart", "Keys", restartRequired)
+	}
+	if len(applied) == 0 {
+		ReloadCounter.WithLabelValues("unchanged").Inc()
+		return nil
+	}
+
+	updated := withReloadable(current, next)
+	r.current.Store(updated)
+
+	r.mu.Lock()
+	subscribers := slices.Clone(r.subscribers)
+	r.mu.Unlock()
+	for _, subscriber := range subscribers {
+		notify(subscriber, updated)
+	}
+
+	ReloadCounter.WithLabelValues("applied").Inc()
+	slog.Info("[Config] Reload applied", "Keys", applied)
+	return nil
+}
+
+// Watch 在后台监听配置文件变化并自动热更新，调用 Close 停止监听
+func (r *Reloader) Watch() error {
+	_, files, err := load(viper.New(), r.options)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.WithMessage(err, "failed to create config watcher")
+	}
+	// 监听目录而不是文件，编辑器通过重命名替换文件后仍能收到事件
+	watched := map[string]bool{}
+	for _, file := range files {
+		file = filepath.Clean(file)
+		watched[file] = true
+		if err := watcher.Add(filepath.Dir(file)); err != nil {
+			watcher.Close()
+			return errors.WithMessagef(err, "failed to watch %s", file)
+		}
+	}
+
+	r.watcher = watcher
+	r.done = make(chan struct{})
+	go r.watch(watched)
+	slog.Info("[Config] Watching", "Files", files)
+	return nil
+}
+
+func (r *Reloader) watch(files map[string]bool) {
+	defer close(r.done)
+
+	var timer *time.Timer
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			}
+			if !files[filepath.Clean(event.Name)] || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				continue
+			}
+			if timer == nil {
+				timer = time.AfterFunc(reloadDebounce, func() { r.Reload() })
+			} else {
+				timer.Reset(reloadDebounce)
+			}
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Error("[Config] Watch", "Error", err)
+		}
+	}
+}
+
+// Close 停止监听配置文件
+func (r *Reloader) Close() error {
+	if r.watcher == nil {
+		return nil
+	}
+	err := r.watcher.Close()
+	<-r.done
+	return err
+}
+
+// notify 通知订阅者，订阅者 panic 不影响其他订阅者
+func notify(subscriber namedSubscriber, cfg *Config) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("[Config] Subscriber panic", "Name", subscriber.name, "Error", r)
+		}
+	}()
+	subscriber.fn(cfg)
+}
+
+// withReloadable 在 current 的副本上更新支持热更新的配置项
+func withReloadable(current, next *Config) *Config {
+	app := *current.App
+	app.LogLevel = next.App.LogLevel
+	app.RateLimit = next.App.RateLimit
+	app.CORS = next.App.CORS
+
+	database := *current.Database
+	database.Log = next.Database.Log
+
+	updated := *current
+	updated.App = &app
+	updated.Database = &database
+	updated.Features = next.Features
+	return &updated
+}
+
+func isReloadable(key string) bool {
+	for _, reloadable := range reloadableKeys {
+		if key == reloadable || strings.HasPrefix(key, reloadable+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// changedKeys 按 yaml 名称比较两份配置，返回值不同的配置项
+func changedKeys(a, b reflect.Value, prefix string) []string {
+	if a.Kind() == reflect.Pointer {
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				return []string{strings.TrimSuffix(prefix, ".")}
+			}
+			return nil
+		}
+		a, b = a.Elem(), b.Elem()
+	}
+	if a.Kind() != reflect.Struct {
+		if reflect.DeepEqual(a.Interface(), b.Interface()) {
+			return nil
+		}
+		return []string{strings.TrimSuffix(prefix, ".")}
+	}
+
+	var keys []string
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		name := yamlName(field)
+		if !field.IsExported() || name == "" {
+			continue
+		}
+		keys = append(keys, changedKeys(a.Field(i), b.Field(i), prefix+name+".")...)
+	}
+	return keys
+}
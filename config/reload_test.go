@@ -0,0 +1,142 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/viper"
+)
+
+const reloadBaseYAML = `
+app:
+  port: "8080"
+  logLevel: "info"
+database:
+  host: "localhost"
+  user: "postgres"
+  database: "tele_repair_hub"
+`
+
+func newTestReloader(t *testing.T) (*Reloader, string) {
+	t.Helper()
+	tempDir := t.TempDir()
+	writeTempConfig(t, tempDir, reloadBaseYAML)
+
+	opts := Options{File: filepath.Join(tempDir, "config.yaml")}
+	cfg, _, err := load(viper.New(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return NewReloader(opts, cfg), tempDir
+}
+
+func TestReloader_AppliesOnlyReloadableKeys(t *testing.T) {
+	reloader, tempDir := newTestReloader(t)
+
+	var notified *Config
+	reloader.Subscribe("test", func(cfg *Config) { notified = cfg })
+
+	writeTempConfig(t, tempDir, `
+app:
+  port: "9090"
+  logLevel: "debug"
+  cors:
+    allowOrigins: ["https://example.com"]
+database:
+  host: "127.0.0.1"
+  user: "postgres"
+  database: "tele_repair_hub"
+  log:
+    debug: true
+features:
+  newTicketFlow: true
+`)
+	applied := testutil.ToFloat64(ReloadCounter.WithLabelValues("applied"))
+	if err := reloader.Reload(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if notified == nil || notified != reloader.Current() {
+		t.Fatalf("expected subscriber to receive current config")
+	}
+	if notified.App.LogLevel != "debug" {
+		t.Errorf("expected logLevel=debug, got %q", notified.App.LogLevel)
+	}
+	if notified.App.CORS.AllowOrigins[0] != "https://example.com" {
+		t.Errorf("unexpected cors origins: %v", notified.App.CORS.AllowOrigins)
+	}
+	if !notified.FeatureEnabled("newTicketFlow") {
+		t.Errorf("expected feature newTicketFlow enabled")
+	}
+	if !notified.Database.Log.Debug {
+		t.Errorf("expected database log debug enabled")
+	}
+	// 端口和数据库地址修改需要重启才能生效
+	if notified.App.Port != "8080" {
+		t.Errorf("expected port unchanged, got %q", notified.App.Port)
+	}
+	if notified.Database.Host != "localhost" {
+		t.Errorf("expected database host unchanged, got %q", notified.Database.Host)
+	}
+	if got := testutil.ToFloat64(ReloadCounter.WithLabelValues("applied")); got != applied+1 {
+		t.Errorf("expected applied counter to increase, got %v", got)
+	}
+}
+
+func TestReloader_RejectsInvalidConfig(t *testing.T) {
+	reloader, tempDir := newTestReloader(t)
+	current := reloader.Current()
+
+	notified := false
+	reloader.Subscribe("test", func(cfg *Config) { notified = true })
+
+	writeTempConfig(t, tempDir, `
+app:
+  logLevel: "verbose"
+`)
+	rejected := testutil.ToFloat64(ReloadCounter.WithLabelValues("rejected"))
+	if err := reloader.Reload(); err == nil {
+		t.Fatalf("expected error for invalid config")
+	}
+	if reloader.Current() != current || notified {
+		t.Errorf("expected current config unchanged and no notification")
+	}
+	if got := testutil.ToFloat64(ReloadCounter.WithLabelValues("rejected")); got != rejected+1 {
+		t.Errorf("expected rejected counter to increase, got %v", got)
+	}
+}
+
+func TestReloader_Watch(t *testing.T) {
+	reloader, tempDir := newTestReloader(t)
+
+	levels := make(chan string, 1)
+	reloader.Subscribe("test", func(cfg *Config) { levels <- cfg.App.LogLevel })
+	if err := reloader.Watch(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reloader.Close()
+
+	if err := os.WriteFile(filepath.Join(tempDir, "config.yaml"),
+		[]byte(`
+app:
+  logLevel: "warn"
+database:
+  host: "localhost"
+  user: "postgres"
+  database: "tele_repair_hub"
+`), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	select {
+	case level := <-levels:
+		if level != "warn" {
+			t.Errorf("expected logLevel=warn, got %q", level)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for reload")
+	}
+}
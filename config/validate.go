@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+)
+
+// Problem 单个不合法的配置项
+type Problem struct {
+	// 配置项路径，如 app.logger.rotation
+	Key     string
+	Message string
+	Value   any
+}
+
+// ValidationError 配置校验失败，包含全部不合法的配置项
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid config, %d problem(s):", len(e.Problems))
+	for _, problem := range e.Problems {
+		fmt.Fprintf(&b, "\n  %s: %s (got %q)", problem.Key, problem.Message, fmt.Sprint(problem.Value))
+	}
+	return b.String()
+}
+
+// 校验规则对应的说明，未列出的规则使用规则名称
+var ruleMessages = map[string]string{
+	"required":        "is required",
+	"required_if":     "is required when %s",
+	"required_unless": "is required unless %s",
+	"timezone":        "must be an IANA time zone such as Asia/Shanghai",
+	"oneof":           "must be one of: %s",
+	"min":             "must be at least %s",
+	"max":             "must be at most %s",
+	"gt":              "must be greater than %s",
+	"ne":              "must not be %s",
+	"unique":          "must be unique by %s",
+	"startswith":      "must start with %s",
+	"port":            "must be a port number between 1 and 65535",
+	"duration":        "must be a duration such as 30s or 1h",
+}
+
+var configValidator = newConfigValidator()
+
+func newConfigValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(yamlName)
+	v.RegisterValidation("port", func(fl validator.FieldLevel) bool {
+		port, err := strconv.Atoi(fl.Field().String())
+		return err == nil && port >= 1 && port <= 65535
+	})
+	v.RegisterValidation("duration", func(fl validator.FieldLevel) bool {
+		_, err := time.ParseDuration(fl.Field().String())
+		return err == nil
+	})
+	return v
+}
+
+// Validate 校验配置，返回包含全部不合法配置项的 *ValidationError
+func (c *Config) Validate() error {
+	var problems []Problem
+	if err := configValidator.Struct(c); err != nil {
+		fieldErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return err
+		}
+		for _, fieldError := range fieldErrors {
+			message, ok := ruleMessages[fieldError.Tag()]
+			if !ok {
+				message = "failed on rule " + fieldError.Tag()
+			}
+			if strings.Contains(message, "%s") {
+				message = fmt.Sprintf(message, fieldError.Param())
+			}
+			// 去掉 Namespace 开头的结构体名称 Config
+			_, key, _ := strings.Cut(fieldError.Namespace(), ".")
+			problems = append(problems, Problem{Key: key, Message: message, Value: fieldError.Value()})
+		}
+	}
+	if c.Database != nil {
+		problems = append(problems, c.Database.replicaProblems()...)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: problems}
+}
+
+// replicaProblems 检查按表配置引用的副本是否存在
+func (d *DatabaseConfig) replicaProblems() []Problem {
+	names := make(map[string]bool, len(d.Replicas))
+	for _, replica := range d.Replicas {
+		names[replica.Name] = true
+	}
+
+	var problems []Problem
+	for i, table := range d.Tables {
+		for j, name := range table.Replicas {
+			if !names[name] {
+				problems = append(problems, Problem{
+					Key:     fmt.Sprintf("database.tables[%d].replicas[%d]", i, j),
+					Message: "must be the name of a configured replica",
+					Value:   name,
+				})
+			}
+		}
+	}
+	return problems
+}
+
+// setDefaults 按 default 标签设置配置项的默认值，配置文件和环境变量均未设置时生效
+func setDefaults(v *viper.Viper) {
+	for _, field := range configFields(reflect.TypeFor[Config](), "") {
+		if value, ok := field.Field.Tag.Lookup("default"); ok {
+			v.SetDefault(field.Key, value)
+		}
+	}
+}
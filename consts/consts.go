@@ -1,5 +1,18 @@
 package consts
 
+import "time"
+
 const (
-	JWT_KEY = "telecommunications_repair_hub"
+	// token 签发者
+	JWT_ISSUER = "telecommunications_repair_hub"
+	// token 有效期
+	JWT_EXPIRE = 24 * time.Hour
+	// 请求头中携带 token 的前缀
+	JWT_HEADER_PREFIX = "Bearer "
+
+	// echo.Context 中保存当前登录用户的 key
+	CONTEXT_USER_KEY = "tele_user"
+
+	// 按请求头协商接口版本时使用的请求头
+	API_VERSION_HEADER = "X-API-Version"
 )
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	fileHeaderType      = reflect.TypeOf(&multipart.FileHeader{})
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// Binder 从多个来源填充请求参数，只绑定带有对应标签的字段，按以下顺序绑定，后绑定的来源覆盖先绑定的：
+//
+//  1. 请求体：application/json 按 json 标签；application/x-www-form-urlencoded 和 multipart/form-data 按 form 标签，
+//     multipart 文件绑定到 form 标签的 *multipart.FileHeader 或 []*multipart.FileHeader 字段
+//  2. query 参数：query 标签，所有请求方法都会绑定
+//  3. 请求头：header 标签，不区分大小写
+//  4. cookie：cookie 标签
+//  5. 路径参数：param 标签
+//
+// 路径参数优先级最高，请求体和 query 参数无法覆盖路径中的资源 ID。
+type Binder struct {
+	echo.DefaultBinder
+}
+
+func (b *Binder) Bind(i interface{}, c echo.Context) error {
+	if err := b.BindBody(c, i); err != nil {
+		return err
+	}
+	if err := b.BindQueryParams(c, i); err != nil {
+		return err
+	}
+	if err := b.BindHeaders(c, i); err != nil {
+		return err
+	}
+	if err := b.BindCookies(c, i); err != nil {
+		return err
+	}
+	return b.BindPathParams(c, i)
+}
+
+// BindCookies 按 cookie 标签绑定 cookie，支持字符串、数值、布尔类型及其指针
+func (b *Binder) BindCookies(c echo.Context, i interface{}) error {
+	cookies := c.Cookies()
+	if len(cookies) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(cookies))
+	for _, cookie := range cookies {
+		values[cookie.Name] = cookie.Value
+	}
+
+	value := reflect.ValueOf(i)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	if err := bindCookieFields(value.Elem(), values); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	return nil
+}
+
+func bindCookieFields(value reflect.Value, cookies map[string]string) error {
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldValue := value.Field(i)
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		name := field.Tag.Get("cookie")
+		if name == "" {
+			if fieldValue.Kind() == reflect.Struct {
+				if err := bindCookieFields(fieldValue, cookies); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		cookie, ok := cookies[name]
+		if !ok {
+			continue
+		}
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if err := setStringValue(fieldValue, cookie); err != nil {
+			return fmt.Errorf("cookie %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setStringValue(value reflect.Value, s string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
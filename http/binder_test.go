@@ -0,0 +1,74 @@
+package http
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type bindRequest struct {
+	ID        int    `param:"id" query:"id" json:"id"`
+	Keyword   string `query:"keyword"`
+	RequestID string `header:"X-Request-Id"`
+	Session   *int   `cookie:"session"`
+	Remark    string `form:"remark"`
+
+	Photo  *multipart.FileHeader   `form:"photo"`
+	Photos []*multipart.FileHeader `form:"photos"`
+}
+
+func TestBinder_Precedence(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/tickets/7?id=8&keyword=fiber", bytes.NewBufferString(`{"id":9}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("x-request-id", "req-1")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "42"})
+	ctx := e.NewContext(req, httptest.NewRecorder())
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("7")
+
+	request := &bindRequest{}
+	assert.NoError(t, (&Binder{}).Bind(request, ctx))
+	// 路径参数优先级最高
+	assert.Equal(t, 7, request.ID)
+	assert.Equal(t, "fiber", request.Keyword)
+	assert.Equal(t, "req-1", request.RequestID)
+	assert.Equal(t, 42, *request.Session)
+
+	ctx = e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	ctx.Request().AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	assert.Error(t, (&Binder{}).Bind(&bindRequest{}, ctx))
+}
+
+func TestBinder_MultipartFiles(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	assert.NoError(t, writer.WriteField("remark", "光猫照片"))
+	for _, name := range []string{"photo", "photos", "photos"} {
+		part, err := writer.CreateFormFile(name, name+".jpg")
+		assert.NoError(t, err)
+		_, _ = part.Write([]byte("jpeg"))
+	}
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	ctx := echo.New().NewContext(req, httptest.NewRecorder())
+
+	request := &bindRequest{}
+	assert.NoError(t, (&Binder{}).Bind(request, ctx))
+	assert.Equal(t, "光猫照片", request.Remark)
+	assert.Equal(t, "photo.jpg", request.Photo.Filename)
+	assert.Len(t, request.Photos, 2)
+
+	doc := NewOpenAPI(OpenAPIInfo{}, []*Route{{Method: http.MethodPost, Path: "/upload", RequestType: reflect.TypeFor[bindRequest]()}})
+	schema := doc.Paths["/upload"]["post"].RequestBody.Content[echo.MIMEMultipartForm].Schema
+	assert.Equal(t, &Schema{Type: "string", Format: "binary"}, schema.Properties["photo"])
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string", Format: "binary"}}, schema.Properties["photos"])
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"slices"
+	"sync/atomic"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// dynamicCORS 允许来源可在运行时修改的 CORS 中间件
+type dynamicCORS struct {
+	origins atomic.Pointer[[]string]
+}
+
+func newDynamicCORS(origins []string) *dynamicCORS {
+	c := &dynamicCORS{}
+	c.SetOrigins(origins)
+	return c
+}
+
+// SetOrigins 修改允许的来源，为空时允许全部来源
+func (c *dynamicCORS) SetOrigins(origins []string) {
+	if len(origins) == 0 {
+		origins = []string{"*"}
+	}
+	origins = slices.Clone(origins)
+	c.origins.Store(&origins)
+}
+
+func (c *dynamicCORS) middleware() echo.MiddlewareFunc {
+	return middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOriginFunc: func(origin string) (bool, error) {
+			origins := *c.origins.Load()
+			return slices.Contains(origins, "*") || slices.Contains(origins, origin), nil
+		},
+	})
+}
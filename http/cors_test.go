@@ -0,0 +1,32 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicCORS_SetOrigins(t *testing.T) {
+	cors := newDynamicCORS([]string{"https://a.example.com"})
+	e := echo.New()
+	e.Use(cors.middleware())
+	e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	allowOrigin := func(origin string) string {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderOrigin, origin)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Header().Get(echo.HeaderAccessControlAllowOrigin)
+	}
+
+	assert.Equal(t, "https://a.example.com", allowOrigin("https://a.example.com"))
+	assert.Empty(t, allowOrigin("https://b.example.com"))
+
+	cors.SetOrigins([]string{"https://b.example.com"})
+	assert.Empty(t, allowOrigin("https://a.example.com"))
+	assert.Equal(t, "https://b.example.com", allowOrigin("https://b.example.com"))
+}
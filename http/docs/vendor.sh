@@ -0,0 +1,25 @@
+#!/bin/sh
+# 下载 OpenAPI 文档页面使用的 swagger-ui 和 redoc 到 http/docs/assets，随二进制一起嵌入，
+# 页面不再从 CDN 加载脚本。升级时修改下面的版本号后重新执行并提交 assets 目录
+set -eu
+
+SWAGGER_UI_VERSION=5.17.14
+REDOC_VERSION=2.1.5
+
+assets_dir=$(cd "$(dirname "$0")" && pwd)/assets
+work_dir=$(mktemp -d)
+trap 'rm -rf "$work_dir"' EXIT
+
+# fetch 从 npm registry 下载指定版本的包并解压到 $work_dir/$1
+fetch() {
+	mkdir -p "$work_dir/$1"
+	curl -fsSL "https://registry.npmjs.org/$1/-/$1-$2.tgz" | tar -xz -C "$work_dir/$1" --strip-components=1
+}
+
+fetch swagger-ui-dist "$SWAGGER_UI_VERSION"
+fetch redoc "$REDOC_VERSION"
+
+mkdir -p "$assets_dir"
+cp "$work_dir/swagger-ui-dist/swagger-ui-bundle.js" "$work_dir/swagger-ui-dist/swagger-ui.css" "$assets_dir/"
+cp "$work_dir/redoc/bundles/redoc.standalone.js" "$assets_dir/"
+echo "swagger-ui-dist@$SWAGGER_UI_VERSION redoc@$REDOC_VERSION" > "$assets_dir/VERSION"
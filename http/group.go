@@ -0,0 +1,199 @@
+package http
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"telecommunications_repair_hub/consts"
+	"telecommunications_repair_hub/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Group 路由分组，分组内的路由共享路径前缀、中间件和接口版本
+//
+//	api := s.Group("/api", JWTAuthMiddleware)
+//	v1 := api.Version("v1")           // /api/v1/...
+//	v2 := api.HeaderVersion("v2")     // /api/...，请求头 X-API-Version: v2
+type Group struct {
+	server          *Server
+	prefix          string
+	middlewares     []echo.MiddlewareFunc
+	version         string
+	versionByHeader bool
+}
+
+// Group 创建路由分组
+func (s *Server) Group(prefix string, middlewares ...echo.MiddlewareFunc) *Group {
+	return &Group{
+		server:      s,
+		prefix:      prefix,
+		middlewares: middlewares,
+	}
+}
+
+// Group 创建子分组，继承当前分组的路径前缀、中间件和接口版本
+func (g *Group) Group(prefix string, middlewares ...echo.MiddlewareFunc) *Group {
+	return &Group{
+		server:          g.server,
+		prefix:          g.prefix + prefix,
+		middlewares:     g.withMiddlewares(middlewares),
+		version:         g.version,
+		versionByHeader: g.versionByHeader,
+	}
+}
+
+// Version 按路径区分版本的子分组，路径前缀追加 /{version}
+func (g *Group) Version(version string, middlewares ...echo.MiddlewareFunc) *Group {
+	group := g.Group("/"+version, middlewares...)
+	group.version = version
+	group.versionByHeader = false
+	return group
+}
+
+// HeaderVersion 按请求头区分版本的子分组，路径前缀不变，
+// 由请求头 X-API-Version 选择版本，未携带请求头时使用最高的版本，如 v10 高于 v2
+func (g *Group) HeaderVersion(version string, middlewares ...echo.MiddlewareFunc) *Group {
+	group := g.Group("", middlewares...)
+	group.version = version
+	group.versionByHeader = true
+	return group
+}
+
+func (g *Group) GET(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return g.Add(http.MethodGet, path, handler, middlewares...)
+}
+
+func (g *Group) POST(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return g.Add(http.MethodPost, path, handler, middlewares...)
+}
+
+func (g *Group) PUT(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return g.Add(http.MethodPut, path, handler, middlewares...)
+}
+
+func (g *Group) PATCH(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return g.Add(http.MethodPatch, path, handler, middlewares...)
+}
+
+func (g *Group) OPTIONS(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return g.Add(http.MethodOptions, path, handler, middlewares...)
+}
+
+func (g *Group) HEAD(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return g.Add(http.MethodHead, path, handler, middlewares...)
+}
+
+func (g *Group) DELETE(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return g.Add(http.MethodDelete, path, handler, middlewares...)
+}
+
+// Add 在分组内注册路由，分组中间件先于路由中间件执行
+func (g *Group) Add(method string, path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return g.handle(method, path, middlewares, g.server.reflectHandler(handler))
+}
+
+func (g *Group) handle(method string, path string, middlewares []echo.MiddlewareFunc, build handlerBuilder) *Route {
+	path = g.prefix + path
+	middlewares = g.withMiddlewares(middlewares)
+
+	if !g.versionByHeader {
+		route := g.server.handle(method, path, middlewares, build)
+		route.Version = g.version
+		return route
+	}
+
+	key := method + " " + path
+	if versioned, ok := g.server.versionedRoutes[key]; ok {
+		_, exists := versioned.handlers[g.version]
+		g.server.Terminate(exists, "重复注册的接口版本: "+key+" "+g.version)
+	}
+
+	route := g.server.newRoute(method, path, middlewares)
+	route.Version = g.version
+	route.VersionByHeader = true
+	g.server.addVersioned(route, build(g.server, route), middlewares)
+	g.server.routes = append(g.server.routes, route)
+	return route
+}
+
+func (g *Group) withMiddlewares(middlewares []echo.MiddlewareFunc) []echo.MiddlewareFunc {
+	merged := make([]echo.MiddlewareFunc, 0, len(g.middlewares)+len(middlewares))
+	merged = append(merged, g.middlewares...)
+	return append(merged, middlewares...)
+}
+
+// versionedRoute 同一方法和路径下按请求头区分的多个版本
+type versionedRoute struct {
+	handlers map[string]echo.HandlerFunc
+	// 未携带版本请求头时使用的最高版本
+	latest string
+}
+
+// addVersioned 注册按请求头协商版本的路由，同一方法和路径只向 echo 注册一次，
+// 各版本的中间件在选定版本后执行
+func (s *Server) addVersioned(route *Route, handlerFunc echo.HandlerFunc, middlewares []echo.MiddlewareFunc) {
+	if s.versionedRoutes == nil {
+		s.versionedRoutes = map[string]*versionedRoute{}
+	}
+
+	key := route.Method + " " + route.Path
+	versioned, ok := s.versionedRoutes[key]
+	if !ok {
+		s.Terminate(s.hasPlainRoute(route.Method, route.Path), "已注册不区分版本的接口，不能再按请求头注册版本: "+key)
+		versioned = &versionedRoute{handlers: map[string]echo.HandlerFunc{}}
+		s.versionedRoutes[key] = versioned
+		s.Echo.Add(route.Method, route.Path, versioned.dispatch)
+	}
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handlerFunc = middlewares[i](handlerFunc)
+	}
+	versioned.handlers[route.Version] = handlerFunc
+	if versioned.latest == "" || compareVersions(route.Version, versioned.latest) > 0 {
+		versioned.latest = route.Version
+	}
+}
+
+// hasPlainRoute 是否已注册不按请求头区分版本的同一方法和路径
+func (s *Server) hasPlainRoute(method string, path string) bool {
+	return slices.ContainsFunc(s.routes, func(route *Route) bool {
+		return !route.VersionByHeader && route.Method == method && route.Path == path
+	})
+}
+
+// compareVersions 比较接口版本，去掉前缀 v 后按 . 分段，数字段按数值比较，其余按字符串比较
+func compareVersions(a string, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNumber, aErr := strconv.Atoi(aParts[i])
+		bNumber, bErr := strconv.Atoi(bParts[i])
+		result := strings.Compare(aParts[i], bParts[i])
+		if aErr == nil && bErr == nil {
+			result = cmp.Compare(aNumber, bNumber)
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return cmp.Compare(len(aParts), len(bParts))
+}
+
+func (v *versionedRoute) dispatch(ctx echo.Context) error {
+	version := strings.TrimSpace(ctx.Request().Header.Get(consts.API_VERSION_HEADER))
+	if version == "" {
+		version = v.latest
+	}
+
+	ctx.Response().Header().Add(echo.HeaderVary, consts.API_VERSION_HEADER)
+	handlerFunc, ok := v.handlers[version]
+	if !ok {
+		return errorResponse(ctx, pkg.ErrUnsupportedAPIVersion.Wrapf("%s: %s", consts.API_VERSION_HEADER, version))
+	}
+	ctx.Response().Header().Set(consts.API_VERSION_HEADER, version)
+	return handlerFunc(ctx)
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"telecommunications_repair_hub/consts"
+	"telecommunications_repair_hub/pkg"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func headerMiddleware(value string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Add("X-Trace", value)
+			return next(c)
+		}
+	}
+}
+
+func versionHandler(version string) func(ctx *TelecommunicationsContext) (string, error) {
+	return func(ctx *TelecommunicationsContext) (string, error) {
+		return version, nil
+	}
+}
+
+func TestGroup_PathVersion(t *testing.T) {
+	s := newTestServer()
+	api := s.Group("/api", headerMiddleware("api"))
+	v1 := api.Version("v1", headerMiddleware("v1"))
+	route := v1.Group("/tickets").GET("/:id", versionHandler("v1"), headerMiddleware("route"))
+
+	assert.Equal(t, "/api/v1/tickets/:id", route.Path)
+	assert.Equal(t, "v1", route.versionName())
+	// 路由表中的中间件包含分组、子分组和路由自身的中间件
+	assert.Len(t, strings.Split(strings.Trim(route.Middlewares, ","), ","), 3)
+
+	rec, body := serve(s, http.MethodGet, "/api/v1/tickets/1", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "v1", body["data"])
+	// 分组中间件先于子分组和路由中间件执行
+	assert.Equal(t, []string{"api", "v1", "route"}, rec.Header().Values("X-Trace"))
+}
+
+func TestGroup_HeaderVersion(t *testing.T) {
+	s := newTestServer()
+	api := s.Group("/api")
+	api.HeaderVersion("v1").GET("/tickets", versionHandler("v1"))
+	route := api.HeaderVersion("v2", headerMiddleware("v2")).GET("/tickets", versionHandler("v2"))
+
+	assert.Equal(t, "/api/tickets", route.Path)
+	assert.Equal(t, "v2 ("+consts.API_VERSION_HEADER+")", route.versionName())
+
+	request := func(version string) (*httptest.ResponseRecorder, string, map[string]any) {
+		req := httptest.NewRequest(http.MethodGet, "/api/tickets", nil)
+		if version != "" {
+			req.Header.Set(consts.API_VERSION_HEADER, version)
+		}
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		body := map[string]any{}
+		_ = json.Unmarshal(rec.Body.Bytes(), &body)
+		return rec, rec.Header().Get(consts.API_VERSION_HEADER), body
+	}
+
+	rec, served, body := request("v1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "v1", served)
+	assert.Empty(t, rec.Header().Values("X-Trace"))
+	assert.Equal(t, "v1", body["data"])
+
+	// 未携带版本请求头时使用最高的版本
+	rec, served, _ = request("")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "v2", served)
+	assert.Equal(t, []string{"v2"}, rec.Header().Values("X-Trace"))
+
+	rec, _, body = request("v9")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, float64(pkg.ErrUnsupportedAPIVersion.Code), body["status"])
+
+	assert.Panics(t, func() {
+		api.HeaderVersion("v1").GET("/tickets", versionHandler("v1"))
+	})
+
+	doc := NewOpenAPI(OpenAPIInfo{}, s.Routes())
+	operation := doc.Paths["/api/tickets"]["get"]
+	assert.Len(t, operation.Parameters, 1)
+	assert.Equal(t, []any{"v1", "v2"}, operation.Parameters[0].Schema.Enum)
+}
+
+func TestGroup_HeaderVersionDefault(t *testing.T) {
+	s := newTestServer()
+	api := s.Group("/api")
+	api.HeaderVersion("v10").GET("/tickets", versionHandler("v10"))
+	api.HeaderVersion("v2").GET("/tickets", versionHandler("v2"))
+
+	// 默认版本与注册顺序无关
+	rec, body := serve(s, http.MethodGet, "/api/tickets", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "v10", rec.Header().Get(consts.API_VERSION_HEADER))
+	assert.Equal(t, "v10", body["data"])
+
+	parameter := NewOpenAPI(OpenAPIInfo{}, s.Routes()).Paths["/api/tickets"]["get"].Parameters[0]
+	assert.Equal(t, "v10", parameter.Schema.Default)
+	assert.Equal(t, []any{"v10", "v2"}, parameter.Schema.Enum)
+}
+
+func TestGroup_HeaderVersionConflict(t *testing.T) {
+	s := newTestServer()
+	api := s.Group("/api")
+	api.GET("/tickets", versionHandler(""))
+	api.HeaderVersion("v1").GET("/users", versionHandler("v1"))
+
+	assert.Panics(t, func() {
+		api.HeaderVersion("v1").GET("/tickets", versionHandler("v1"))
+	})
+	assert.Panics(t, func() {
+		api.GET("/users", versionHandler(""))
+	})
+	// 路径中的版本与按请求头协商的版本不冲突
+	assert.NotPanics(t, func() {
+		api.Version("v1").GET("/users", versionHandler("v1"))
+	})
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, -1, compareVersions("v2", "v10"))
+	assert.Equal(t, 1, compareVersions("v1.10", "v1.9"))
+	assert.Equal(t, 0, compareVersions("v1", "v1"))
+	assert.Equal(t, -1, compareVersions("v1", "v1.1"))
+	assert.Equal(t, 1, compareVersions("v1beta", "v1alpha"))
+}
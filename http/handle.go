@@ -0,0 +1,62 @@
+package http
+
+import (
+	"fmt"
+	"reflect"
+
+	"telecommunications_repair_hub/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Registrar 可以注册路由的对象，Server、Group 和 BaseRouter 都实现了该接口
+type Registrar interface {
+	handle(method string, path string, middlewares []echo.MiddlewareFunc, build handlerBuilder) *Route
+}
+
+// Handle 以泛型注册路由，适用于高频接口：
+//
+//   - 请求参数由 new(Req) 创建，处理函数直接调用，不使用 reflect.New 和 reflect.Value.Call
+//   - 注册时按 Req 的字段生成绑定器，请求处理时按字段偏移写入参数，来源和优先级与 Binder 一致
+//   - 校验仍由 validator 按 validate 标签完成，Req 没有 validate 标签时跳过校验
+//
+// 路由信息、权限控制和统一响应结构与 Server.Add 一致，Req 中带绑定标签的字段类型不受支持时注册失败。
+//
+//	Handle(s, http.MethodPost, "/tickets", createTicket).Roles(models.UserRoleEndUser)
+func Handle[Req any, Resp any](r Registrar, method string, path string,
+	handler func(ctx *TelecommunicationsContext, request *Req) (Resp, error),
+	middlewares ...echo.MiddlewareFunc) *Route {
+	if handler == nil {
+		panic("处理函数不能为空")
+	}
+
+	return r.handle(method, path, middlewares, func(s *Server, route *Route) echo.HandlerFunc {
+		route.HandlerName = fmt.Sprintf("%T", handler)
+		route.RequestType = reflect.TypeFor[Req]()
+		route.ResponseType = reflect.TypeFor[Resp]()
+
+		binder, err := compileBinder[Req]()
+		s.Terminate(err != nil, fmt.Sprintf("路由 %s %s 注册失败: %v", method, route.Path, err))
+		validate := hasValidateTags(route.RequestType, map[reflect.Type]bool{})
+
+		return func(ctx echo.Context) error {
+			if err := route.authorize(ctx); err != nil {
+				return errorResponse(ctx, err)
+			}
+
+			request := new(Req)
+			if err := binder.bind(ctx, request); err != nil {
+				return errorResponse(ctx, pkg.ErrParamError.Wrap(err))
+			}
+
+			if validate {
+				if err := s.RequestValidator(ctx, request); err != nil {
+					return err
+				}
+			}
+
+			result, err := handler(s.newContext(ctx), request)
+			return respond(ctx, result, true, err)
+		}
+	})
+}
@@ -0,0 +1,337 @@
+package http
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/labstack/echo/v4"
+)
+
+// setFunc 将字符串参数写入 field 指向的字段，values 至少有一个元素
+type setFunc func(field unsafe.Pointer, values []string) error
+
+// fieldSetter 注册时按字段类型生成的参数写入函数，offset 为字段相对请求参数结构体的偏移
+type fieldSetter struct {
+	name   string
+	offset uintptr
+	set    setFunc
+}
+
+// fileSetter multipart 文件字段，multiple 为 []*multipart.FileHeader
+type fileSetter struct {
+	name     string
+	offset   uintptr
+	multiple bool
+}
+
+// requestBinder 注册时根据请求参数类型生成的绑定器，来源和优先级与 Binder 一致，
+// 请求处理时按预先计算的字段偏移写入参数，不再遍历结构体字段和解析标签
+type requestBinder[Req any] struct {
+	form   []fieldSetter
+	files  []fileSetter
+	query  []fieldSetter
+	header []fieldSetter
+	cookie []fieldSetter
+	param  []fieldSetter
+}
+
+// 绑定参数的标签，json 标签由 encoding/json 处理
+var bindTags = []string{"form", "query", "header", "cookie", "param"}
+
+// compileBinder 生成 Req 的绑定器，Req 中带有绑定标签的字段类型不受支持时返回错误
+func compileBinder[Req any]() (*requestBinder[Req], error) {
+	b := &requestBinder[Req]{}
+	t := reflect.TypeFor[Req]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("请求参数 %s 必须是结构体", t)
+	}
+	if err := b.compileFields(t, 0); err != nil {
+		return nil, fmt.Errorf("请求参数 %s: %w", t, err)
+	}
+	return b, nil
+}
+
+func (b *requestBinder[Req]) compileFields(t reflect.Type, base uintptr) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		offset := base + field.Offset
+
+		tagged := false
+		for _, key := range bindTags {
+			name, ok := field.Tag.Lookup(key)
+			if !ok || name == "" || name == "-" {
+				continue
+			}
+			tagged = true
+
+			if key == "form" && (field.Type == fileHeaderType || field.Type == fileHeaderSliceType) {
+				b.files = append(b.files, fileSetter{name: name, offset: offset, multiple: field.Type == fileHeaderSliceType})
+				continue
+			}
+			set, ok := compileSetter(field.Type)
+			if !ok {
+				return fmt.Errorf("字段 %s 的类型 %s 不支持 %s 绑定", field.Name, field.Type, key)
+			}
+			setter := fieldSetter{name: name, offset: offset, set: set}
+			switch key {
+			case "form":
+				b.form = append(b.form, setter)
+			case "query":
+				b.query = append(b.query, setter)
+			case "header":
+				setter.name = http.CanonicalHeaderKey(name)
+				b.header = append(b.header, setter)
+			case "cookie":
+				b.cookie = append(b.cookie, setter)
+			case "param":
+				b.param = append(b.param, setter)
+			}
+		}
+
+		// 与 echo 一致，没有绑定标签的结构体字段继续绑定其内部字段
+		if !tagged && field.Type.Kind() == reflect.Struct {
+			if err := b.compileFields(field.Type, offset); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bind 按 请求体 → query → 请求头 → cookie → 路径参数 的顺序绑定，后绑定的来源覆盖先绑定的
+func (b *requestBinder[Req]) bind(c echo.Context, request *Req) error {
+	p := unsafe.Pointer(request)
+	if err := b.bindBody(c, request, p); err != nil {
+		return err
+	}
+
+	if len(b.query) > 0 {
+		query := c.QueryParams()
+		if err := setFields(p, b.query, func(name string) []string { return query[name] }); err != nil {
+			return err
+		}
+	}
+	if len(b.header) > 0 {
+		header := c.Request().Header
+		if err := setFields(p, b.header, func(name string) []string { return header[name] }); err != nil {
+			return err
+		}
+	}
+	if len(b.cookie) > 0 {
+		err := setFields(p, b.cookie, func(name string) []string {
+			if cookie, err := c.Cookie(name); err == nil {
+				return []string{cookie.Value}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return setFields(p, b.param, func(name string) []string {
+		if value := c.Param(name); value != "" {
+			return []string{value}
+		}
+		return nil
+	})
+}
+
+func (b *requestBinder[Req]) bindBody(c echo.Context, request *Req, p unsafe.Pointer) error {
+	req := c.Request()
+	if req.ContentLength == 0 {
+		return nil
+	}
+
+	contentType := req.Header.Get(echo.HeaderContentType)
+	switch {
+	case strings.HasPrefix(contentType, echo.MIMEApplicationJSON):
+		if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+		return nil
+	case strings.HasPrefix(contentType, echo.MIMEApplicationForm), strings.HasPrefix(contentType, echo.MIMEMultipartForm):
+		form, err := c.FormParams()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+		if err := setFields(p, b.form, func(name string) []string { return form[name] }); err != nil {
+			return err
+		}
+		if len(b.files) == 0 || !strings.HasPrefix(contentType, echo.MIMEMultipartForm) {
+			return nil
+		}
+		multipartForm, err := c.MultipartForm()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+		for _, file := range b.files {
+			headers := multipartForm.File[file.name]
+			if len(headers) == 0 {
+				continue
+			}
+			if file.multiple {
+				*(*[]*multipart.FileHeader)(unsafe.Add(p, file.offset)) = headers
+			} else {
+				*(**multipart.FileHeader)(unsafe.Add(p, file.offset)) = headers[0]
+			}
+		}
+		return nil
+	default:
+		return echo.ErrUnsupportedMediaType
+	}
+}
+
+func setFields(p unsafe.Pointer, setters []fieldSetter, lookup func(name string) []string) error {
+	for _, setter := range setters {
+		values := lookup(setter.name)
+		if len(values) == 0 {
+			continue
+		}
+		if err := setter.set(unsafe.Add(p, setter.offset), values); err != nil {
+			err = fmt.Errorf("%s: %w", setter.name, err)
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+	}
+	return nil
+}
+
+// kindSetters 按基础类型生成的写入函数：值、指针和切片，命名类型按其基础类型写入
+var kindSetters = map[reflect.Kind][3]setFunc{
+	reflect.String:  setters(func(s string) (string, error) { return s, nil }),
+	reflect.Bool:    setters(parseBool),
+	reflect.Int:     setters(parseInt[int](strconv.IntSize)),
+	reflect.Int8:    setters(parseInt[int8](8)),
+	reflect.Int16:   setters(parseInt[int16](16)),
+	reflect.Int32:   setters(parseInt[int32](32)),
+	reflect.Int64:   setters(parseInt[int64](64)),
+	reflect.Uint:    setters(parseUint[uint](strconv.IntSize)),
+	reflect.Uint8:   setters(parseUint[uint8](8)),
+	reflect.Uint16:  setters(parseUint[uint16](16)),
+	reflect.Uint32:  setters(parseUint[uint32](32)),
+	reflect.Uint64:  setters(parseUint[uint64](64)),
+	reflect.Float32: setters(parseFloat[float32](32)),
+	reflect.Float64: setters(parseFloat[float64](64)),
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+func compileSetter(t reflect.Type) (setFunc, bool) {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return func(field unsafe.Pointer, values []string) error {
+			return reflect.NewAt(t, field).Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(values[0]))
+		}, true
+	}
+
+	index := 0
+	switch t.Kind() {
+	case reflect.Ptr:
+		index, t = 1, t.Elem()
+	case reflect.Slice:
+		index, t = 2, t.Elem()
+	}
+	kindSetter, ok := kindSetters[t.Kind()]
+	return kindSetter[index], ok
+}
+
+func setters[T any](parse func(string) (T, error)) [3]setFunc {
+	value := func(field unsafe.Pointer, values []string) error {
+		v, err := parse(values[0])
+		if err != nil {
+			return err
+		}
+		*(*T)(field) = v
+		return nil
+	}
+	pointer := func(field unsafe.Pointer, values []string) error {
+		v, err := parse(values[0])
+		if err != nil {
+			return err
+		}
+		*(**T)(field) = &v
+		return nil
+	}
+	slice := func(field unsafe.Pointer, values []string) error {
+		s := make([]T, len(values))
+		for i, value := range values {
+			v, err := parse(value)
+			if err != nil {
+				return err
+			}
+			s[i] = v
+		}
+		*(*[]T)(field) = s
+		return nil
+	}
+	return [3]setFunc{value, pointer, slice}
+}
+
+// 与 echo 一致，空字符串按零值绑定
+
+func parseBool(s string) (bool, error) {
+	if s == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
+
+func parseInt[T int | int8 | int16 | int32 | int64](bits int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		if s == "" {
+			return 0, nil
+		}
+		n, err := strconv.ParseInt(s, 10, bits)
+		return T(n), err
+	}
+}
+
+func parseUint[T uint | uint8 | uint16 | uint32 | uint64](bits int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		if s == "" {
+			return 0, nil
+		}
+		n, err := strconv.ParseUint(s, 10, bits)
+		return T(n), err
+	}
+}
+
+func parseFloat[T float32 | float64](bits int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		if s == "" {
+			return 0, nil
+		}
+		n, err := strconv.ParseFloat(s, bits)
+		return T(n), err
+	}
+}
+
+// hasValidateTags t 或其嵌套的结构体字段是否带有 validate 标签，没有时 Handle 跳过参数校验
+func hasValidateTags(t reflect.Type, visited map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if tag, ok := field.Tag.Lookup("validate"); ok && tag != "" && tag != "-" {
+			return true
+		}
+		if hasValidateTags(field.Type, visited) {
+			return true
+		}
+	}
+	return false
+}
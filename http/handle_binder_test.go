@@ -0,0 +1,109 @@
+package http
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestBinder_Precedence(t *testing.T) {
+	binder, err := compileBinder[bindRequest]()
+	require.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/tickets/7?id=8&keyword=fiber", bytes.NewBufferString(`{"id":9}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("x-request-id", "req-1")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "42"})
+	ctx := e.NewContext(req, httptest.NewRecorder())
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("7")
+
+	request := &bindRequest{}
+	assert.NoError(t, binder.bind(ctx, request))
+	// 与 Binder 一致，路径参数优先级最高
+	assert.Equal(t, 7, request.ID)
+	assert.Equal(t, "fiber", request.Keyword)
+	assert.Equal(t, "req-1", request.RequestID)
+	assert.Equal(t, 42, *request.Session)
+
+	ctx = e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	ctx.Request().AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	assert.Error(t, binder.bind(ctx, &bindRequest{}))
+
+	req = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`id=9`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMETextPlain)
+	assert.ErrorIs(t, binder.bind(e.NewContext(req, httptest.NewRecorder()), &bindRequest{}), echo.ErrUnsupportedMediaType)
+}
+
+func TestRequestBinder_MultipartFiles(t *testing.T) {
+	binder, err := compileBinder[bindRequest]()
+	require.NoError(t, err)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	assert.NoError(t, writer.WriteField("remark", "光猫照片"))
+	for _, name := range []string{"photo", "photos", "photos"} {
+		part, err := writer.CreateFormFile(name, name+".jpg")
+		assert.NoError(t, err)
+		_, _ = part.Write([]byte("jpeg"))
+	}
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+
+	request := &bindRequest{}
+	assert.NoError(t, binder.bind(echo.New().NewContext(req, httptest.NewRecorder()), request))
+	assert.Equal(t, "光猫照片", request.Remark)
+	assert.Equal(t, "photo.jpg", request.Photo.Filename)
+	assert.Len(t, request.Photos, 2)
+}
+
+func TestRequestBinder_Types(t *testing.T) {
+	type Page struct {
+		Number uint `query:"page"`
+	}
+	type typesRequest struct {
+		Page
+		IDs    []int64    `query:"id"`
+		Ratio  float32    `query:"ratio"`
+		Urgent *bool      `query:"urgent"`
+		Since  time.Time  `query:"since"`
+		Level  regionKind `query:"level"`
+	}
+	binder, err := compileBinder[typesRequest]()
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/?page=2&id=1&id=2&ratio=0.5&urgent=true&since=2026-01-02T03:04:05Z&level=city", nil)
+	request := &typesRequest{}
+	assert.NoError(t, binder.bind(echo.New().NewContext(req, httptest.NewRecorder()), request))
+	assert.Equal(t, uint(2), request.Number)
+	assert.Equal(t, []int64{1, 2}, request.IDs)
+	assert.Equal(t, float32(0.5), request.Ratio)
+	assert.True(t, *request.Urgent)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), request.Since)
+	assert.Equal(t, regionKind("city"), request.Level)
+
+	_, err = compileBinder[struct {
+		Filter map[string]string `query:"filter"`
+	}]()
+	assert.Error(t, err)
+}
+
+func TestHasValidateTags(t *testing.T) {
+	assert.True(t, hasValidateTags(reflect.TypeFor[echoRequest](), map[reflect.Type]bool{}))
+	assert.True(t, hasValidateTags(reflect.TypeFor[struct{ Items []*echoRequest }](), map[reflect.Type]bool{}))
+	assert.False(t, hasValidateTags(reflect.TypeFor[bindRequest](), map[reflect.Type]bool{}))
+}
+
+// regionKind 命名字符串类型按 string 绑定
+type regionKind string
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"telecommunications_repair_hub/pkg"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func typedEcho(ctx *TelecommunicationsContext, request *echoRequest) (*echoResponse, error) {
+	if request.Name == "missing" {
+		return nil, pkg.ErrUserNotFound
+	}
+	return &echoResponse{ID: request.ID, Name: request.Name}, nil
+}
+
+func TestHandle(t *testing.T) {
+	s := newTestServer()
+	route := Handle(s.Group("/api").Version("v1"), http.MethodPost, "/echo/:id", typedEcho)
+	assert.Equal(t, "/api/v1/echo/:id", route.Path)
+	assert.Equal(t, "echoRequest", route.RequestType.Name())
+	assert.Equal(t, "echoResponse", route.ResponseType.Elem().Name())
+	assert.Equal(t, "func(*http.TelecommunicationsContext, *http.echoRequest) (*http.echoResponse, error)", route.HandlerName)
+
+	rec, body := serve(s, http.MethodPost, "/api/v1/echo/3", `{"name":"tom"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, map[string]any{"id": float64(3), "name": "tom"}, body["data"])
+
+	rec, body = serve(s, http.MethodPost, "/api/v1/echo/3", `{"name":"missing"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, float64(pkg.ErrUserNotFound.Code), body["status"])
+
+	rec, body = serve(s, http.MethodPost, "/api/v1/echo/0", `{"name":"tom"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, float64(pkg.ErrParamError.Code), body["status"])
+}
+
+// benchmarkEcho 复用同一个请求，避免 httptest.NewRequest 的开销掩盖路由处理本身
+func benchmarkEcho(b *testing.B, s *Server) {
+	body := strings.NewReader(`{"name":"tom"}`)
+	req := httptest.NewRequest(http.MethodPost, "/echo/3", body)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		body.Reset(`{"name":"tom"}`)
+		req.Body = io.NopCloser(body)
+		rec.Body.Reset()
+		s.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			b.Fatalf("unexpected status %d", rec.Code)
+		}
+	}
+}
+
+// BenchmarkServer_Add 反射注册，每次请求 reflect.New 创建请求参数并 reflect.Value.Call 调用处理函数
+func BenchmarkServer_Add(b *testing.B) {
+	s := newTestServer()
+	s.POST("/echo/:id", typedEcho)
+	benchmarkEcho(b, s)
+}
+
+// BenchmarkHandle 泛型注册
+func BenchmarkHandle(b *testing.B) {
+	s := newTestServer()
+	Handle(s, http.MethodPost, "/echo/:id", typedEcho)
+	benchmarkEcho(b, s)
+}
+
+func benchmarkBind(b *testing.B, bind func(ctx echo.Context, request *echoRequest) error) {
+	body := strings.NewReader(`{"name":"tom"}`)
+	req := httptest.NewRequest(http.MethodPost, "/echo/3", body)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	ctx := echo.New().NewContext(req, httptest.NewRecorder())
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("3")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		body.Reset(`{"name":"tom"}`)
+		req.Body = io.NopCloser(body)
+		if err := bind(ctx, &echoRequest{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkBinder Server.Add 使用的 Binder，每次请求遍历字段并解析标签
+func BenchmarkBinder(b *testing.B) {
+	binder := &Binder{}
+	benchmarkBind(b, func(ctx echo.Context, request *echoRequest) error { return binder.Bind(request, ctx) })
+}
+
+// BenchmarkRequestBinder Handle 注册时生成的绑定器
+func BenchmarkRequestBinder(b *testing.B) {
+	binder, err := compileBinder[echoRequest]()
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkBind(b, binder.bind)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/pkg/db"
+	"telecommunications_repair_hub/pkg/health"
+	"telecommunications_repair_hub/pkg/lifecycle"
+)
+
+func (r *BaseRouter) RegisterHealthRoutes() {
+	r.GET("/livez", r.livez).Tags("健康检查").Summary("存活检查")
+	r.GET("/readyz", r.readyz).Tags("健康检查").Summary("就绪检查，服务关闭过程中返回失败")
+}
+
+// registerHealthChecks 注册数据库和日志目录磁盘空间的就绪检查，以及 goroutine 数量的存活检查
+func registerHealthChecks(cfg *config.Config, dbInstance *db.DB) {
+	healthConfig := cfg.GetHealthConfig()
+	if healthConfig == nil {
+		healthConfig = &config.HealthConfig{}
+	}
+
+	health.Register("database", health.KindReadiness, healthConfig.Timeout, dbInstance.Ping)
+	if healthConfig.DiskMinFree > 0 {
+		diskPath := healthConfig.DiskPath
+		if diskPath == "" {
+			diskPath = "."
+		}
+		health.Register("disk", health.KindReadiness, healthConfig.Timeout,
+			health.DiskSpaceCheck(diskPath, uint64(healthConfig.DiskMinFree)<<20))
+	}
+	if healthConfig.MaxGoroutines > 0 {
+		health.Register("goroutines", health.KindLiveness, healthConfig.Timeout, health.GoroutineCheck(healthConfig.MaxGoroutines))
+	}
+}
+
+func (s *Server) livez(ctx *TelecommunicationsContext) error {
+	return healthResponse(ctx, s.health.Run(ctx.Request().Context(), health.KindLiveness))
+}
+
+func (s *Server) readyz(ctx *TelecommunicationsContext) error {
+	report := s.health.Run(ctx.Request().Context(), health.KindReadiness)
+	if !lifecycle.Ready() {
+		message := "not ready"
+		if lifecycle.ShuttingDown() {
+			message = "shutting down"
+		}
+		report.Status = health.StatusDown
+		report.Checks = append([]*health.Result{{Name: "lifecycle", Status: health.StatusDown, Error: message}}, report.Checks...)
+	}
+	return healthResponse(ctx, report)
+}
+
+// healthResponse 探针只关心状态码，直接返回检查结果，不使用统一响应结构
+func healthResponse(ctx *TelecommunicationsContext, report *health.Report) error {
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	return ctx.JSON(status, report)
+}
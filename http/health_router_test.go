@@ -0,0 +1,53 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"telecommunications_repair_hub/pkg/health"
+	"telecommunications_repair_hub/pkg/lifecycle"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthRoutes(t *testing.T) {
+	lifecycle.Reset()
+	defer lifecycle.Reset()
+
+	s := newTestServer()
+	NewBaseRouter(s).RegisterHealthRoutes()
+	databaseErr := error(nil)
+	s.health.Register("database", health.KindReadiness, 0, func(ctx context.Context) error { return databaseErr })
+
+	rec, body := serve(s, http.MethodGet, "/livez", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "up", body["status"])
+
+	// 服务启动完成前未就绪
+	rec, _ = serve(s, http.MethodGet, "/readyz", "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	lifecycle.SetReady(true)
+	rec, body = serve(s, http.MethodGet, "/readyz", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "database", body["checks"].([]any)[0].(map[string]any)["name"])
+
+	databaseErr = errors.New("connection refused")
+	rec, _ = serve(s, http.MethodGet, "/readyz", "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	databaseErr = nil
+	lifecycle.BeginShutdown()
+	rec, body = serve(s, http.MethodGet, "/readyz", "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, map[string]any{"name": "lifecycle", "status": "down", "latency_ms": float64(0), "error": "shutting down"},
+		body["checks"].([]any)[0])
+
+	// 存活检查失败时 /livez 返回失败
+	s.health.Register("goroutines", health.KindLiveness, 0, health.GoroutineCheck(0))
+	rec, body = serve(s, http.MethodGet, "/livez", "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "goroutines", body["checks"].([]any)[0].(map[string]any)["name"])
+}
@@ -2,27 +2,41 @@ package http
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"telecommunications_repair_hub/config"
-	"telecommunications_repair_hub/pkg/response"
+	"telecommunications_repair_hub/pkg/db"
+	"telecommunications_repair_hub/pkg/lifecycle"
+	"telecommunications_repair_hub/pkg/network_traffic"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type HttpServer struct {
 	Port   string
 	Host   string
 	config *config.Config
+	db     *db.DB
+
+	mu      sync.Mutex
+	server  *Server
+	dbStats prometheus.Collector
+	errChan chan error
 }
 
-func NewHttpServer(config *config.Config) *HttpServer {
+func NewHttpServer(config *config.Config, dbInstance *db.DB) *HttpServer {
 	return &HttpServer{
-		Port:   config.App.Port,
-		Host:   config.App.Host,
-		config: config,
+		Port:    config.App.Port,
+		Host:    config.App.Host,
+		config:  config,
+		db:      dbInstance,
+		errChan: make(chan error, 1),
 	}
 }
 
@@ -31,37 +45,120 @@ func (h *HttpServer) init(e *Server) {
 		if ctx.Response().Committed {
 			return
 		}
-		response.NewResponse(ctx).
-			SetStatus(http.StatusInternalServerError).
-			SetMessage(err.Error()).
-			Error(err)
-
-		slog.Error("[HttpServer] HTTPErrorHandler", "Method", ctx.Request().Method,
-			"Path", ctx.Request().URL.Path, "Error", err)
+		errorResponse(ctx, err)
 
+		teleErr := toTeleCommunicationError(err)
+		logLevel := slog.LevelWarn
+		if teleErr.HTTPStatus >= http.StatusInternalServerError {
+			logLevel = slog.LevelError
+		}
+		slog.Log(ctx.Request().Context(), logLevel, "[HttpServer] HTTPErrorHandler", "Method", ctx.Request().Method,
+			"Path", ctx.Request().URL.Path, "Code", teleErr.Code, "Error", err)
 	}
 
 }
 
+// Start 创建 Server、注册路由并监听端口，端口监听失败时直接返回错误，
+// 随后在后台处理请求，后台服务异常退出时通过 Err 返回的 channel 通知
 func (h *HttpServer) Start(ctx context.Context) error {
-	e := NewServer(h.config)
+	e := NewServer(h.config, h.db)
 	h.init(e)
+	applyRateLimit(h.config)
 
+	slog.Info("[HttpServer] Register Routes")
+	NewBaseRouter(e).RegisterRoutes()
+	e.RegisterOpenAPI()
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(h.Host, h.Port))
+	if err != nil {
+		return err
+	}
+	e.Listener = listener
+
+	// 连接池状态随数据库实例注册，Shutdown 时注销
+	var dbStats prometheus.Collector
+	if h.db != nil {
+		dbStats = h.db.StatsCollector()
+		if err := reg.Register(dbStats); err != nil {
+			listener.Close()
+			return err
+		}
+	}
+
+	h.mu.Lock()
+	h.server = e
+	h.dbStats = dbStats
+	h.mu.Unlock()
+
+	slog.Info("[HttpServer] Start", "Host", h.Host, "Port", h.Port)
+	lifecycle.SetReady(true)
 	go func() {
-		<-ctx.Done()
-		e.Shutdown(ctx)
+		err := e.Start(h.Host, h.Port)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("[HttpServer] Start", "Error", err)
+			h.errChan <- err
+		}
 	}()
+	return nil
+}
 
-	slog.Info("[HttpServer] Register Routes")
+// ApplyConfig 应用热更新后的配置
+func (h *HttpServer) ApplyConfig(cfg *config.Config) {
+	applyRateLimit(cfg)
+
+	h.mu.Lock()
+	e := h.server
+	h.mu.Unlock()
+	if e != nil {
+		e.ApplyConfig(cfg)
+	}
+}
+
+// applyRateLimit 按配置修改下载限速
+func applyRateLimit(cfg *config.Config) {
+	rateLimit := cfg.GetRateLimitConfig()
+	if rateLimit == nil {
+		return
+	}
+	network_traffic.SetDefaultLimits(network_traffic.Limits{
+		Limit: network_traffic.TrafficLimitUnit(rateLimit.Limit) * network_traffic.TrafficLimitUnitMB,
+		Burst: network_traffic.TrafficLimitUnit(rateLimit.Burst) * network_traffic.TrafficLimitUnitMB,
+	})
+}
+
+// PrintRoutes 注册全部路由并输出路由表，不连接数据库也不监听端口
+func (h *HttpServer) PrintRoutes() {
+	e := NewServer(h.config, h.db)
 	NewBaseRouter(e).RegisterRoutes()
+	e.RenderRoutes(h.Port)
+}
 
-	slog.Info("[HttpServer] Start", "Host", h.Host, "Port", h.Port)
-	err := e.Start(h.Host, h.Port)
-	if err != nil {
-		slog.Error("[HttpServer] Start", "Error", err)
+// Err 后台服务异常退出时收到错误，调用 Shutdown 正常关闭时不会收到
+func (h *HttpServer) Err() <-chan error {
+	return h.errChan
+}
+
+// Shutdown 停止接收新连接并等待处理中的请求完成，ctx 到期后强制关闭剩余连接
+func (h *HttpServer) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	e, dbStats := h.server, h.dbStats
+	h.mu.Unlock()
+	if e == nil {
+		return nil
+	}
+	if dbStats != nil {
+		reg.Unregister(dbStats)
+	}
+
+	slog.Info("[HttpServer] Shutdown")
+	if err := e.Shutdown(ctx); err != nil {
+		// 超时后强制关闭仍未完成的连接
+		if closeErr := e.Close(); closeErr != nil {
+			slog.Error("[HttpServer] Close", "Error", closeErr)
+		}
 		return err
 	}
-	return err
+	return nil
 }
 
 func (h *HttpServer) HttpLogLevel() log.Lvl {
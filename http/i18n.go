@@ -0,0 +1,28 @@
+package http
+
+import (
+	"strings"
+	"telecommunications_repair_hub/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 支持的语言，按 Accept-Language 的顺序匹配第一个支持的语言
+var supportedLanguages = []string{"zh", "en"}
+
+// requestLanguage 根据 Accept-Language 请求头选择返回提示的语言，默认中文
+func requestLanguage(ctx echo.Context) string {
+	acceptLanguage := ctx.Request().Header.Get("Accept-Language")
+	for _, tag := range strings.Split(acceptLanguage, ",") {
+		// zh-CN;q=0.9 -> zh
+		tag, _, _ = strings.Cut(strings.TrimSpace(tag), ";")
+		tag, _, _ = strings.Cut(tag, "-")
+		tag = strings.ToLower(tag)
+		for _, language := range supportedLanguages {
+			if tag == language {
+				return language
+			}
+		}
+	}
+	return pkg.DefaultLanguage
+}
@@ -1,10 +1,21 @@
 package http
 
 import (
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/consts"
+	"telecommunications_repair_hub/pkg"
+	"telecommunications_repair_hub/pkg/auth"
+	"telecommunications_repair_hub/pkg/db"
+	"telecommunications_repair_hub/pkg/logger"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 )
@@ -23,17 +34,94 @@ func init() {
 	reg.MustRegister(
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 		collectors.NewGoCollector(),
-		RequestCounter)
+		RequestCounter,
+		config.ReloadCounter,
+		db.ResolverQueryCounter,
+		db.ReplicaHealthy,
+		db.QueryDuration)
 }
 
+// RequestIDMiddleware 沿用请求头 X-Request-Id，没有时生成新的请求 ID，
+// 请求 ID 保存到请求的 context，使用该 context 记录的日志带有 RequestID 字段
+func RequestIDMiddleware() echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, requestID string) {
+			c.SetRequest(c.Request().WithContext(logger.WithRequestID(c.Request().Context(), requestID)))
+		},
+	})
+}
+
+// SQLTraceMiddleware SQL 调试模式下记录请求执行的全部 SQL，请求结束时输出一条日志
+func SQLTraceMiddleware(dbInstance *db.DB) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if dbInstance == nil || !dbInstance.SQLDebug() {
+				return next(c)
+			}
+			ctx, trace := db.WithSQLTrace(c.Request().Context())
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			err := next(c)
+
+			statements := trace.Statements()
+			lines := make([]string, 0, len(statements))
+			var total time.Duration
+			for _, statement := range statements {
+				total += statement.Duration
+				line := fmt.Sprintf("[%s rows:%d] %s", statement.Duration, statement.Rows, statement.SQL)
+				if statement.Error != nil {
+					line += " error: " + statement.Error.Error()
+				}
+				lines = append(lines, line)
+			}
+			slog.InfoContext(c.Request().Context(), "[HttpServer] SQL trace", "Method", c.Request().Method,
+				"Path", c.Request().URL.Path, "Count", len(statements), "Duration", total, "Statements", lines)
+			return err
+		}
+	}
+}
+
+// RequestCounterMiddleware 在请求处理完成后按实际返回的状态码计数，
+// 处理函数返回的错误先交给 HTTPErrorHandler 写入响应，保证状态码准确
 func RequestCounterMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+
+		path := c.Path()
+		if path == "" {
+			path = c.Request().URL.Path
+		}
 		RequestCounter.WithLabelValues(
 			c.Request().Method,
-			c.Request().URL.Path,
+			path,
 			http.StatusText(c.Response().Status),
 			strconv.Itoa(c.Response().Status),
 		).Inc()
+		return nil
+	}
+}
+
+// JWTAuthMiddleware 校验 Authorization 请求头中的访问 token，
+// 通过后将解析出的用户信息保存到上下文，供 TelecommunicationsContext.User 读取
+func JWTAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims, err := authenticate(c)
+		if err != nil {
+			return errorResponse(c, err)
+		}
+
+		c.Set(consts.CONTEXT_USER_KEY, claims)
 		return next(c)
 	}
 }
+
+// authenticate 从 Authorization 请求头解析访问 token
+func authenticate(c echo.Context) (*auth.Claims, error) {
+	authorization := c.Request().Header.Get(echo.HeaderAuthorization)
+	if !strings.HasPrefix(authorization, consts.JWT_HEADER_PREFIX) {
+		return nil, pkg.ErrInvalidToken
+	}
+	return auth.ParseToken(strings.TrimPrefix(authorization, consts.JWT_HEADER_PREFIX))
+}
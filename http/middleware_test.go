@@ -0,0 +1,31 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"telecommunications_repair_hub/pkg/logger"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(RequestIDMiddleware())
+	e.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, logger.RequestID(c.Request().Context()))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.NotEmpty(t, rec.Body.String())
+	assert.Equal(t, rec.Header().Get(echo.HeaderXRequestID), rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-1")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, "req-1", rec.Body.String())
+}
@@ -0,0 +1,619 @@
+package http
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/consts"
+	"telecommunications_repair_hub/pkg/response"
+	"telecommunications_repair_hub/pkg/validation"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OpenAPI 3.1 文档，只包含本项目用到的字段
+type OpenAPI struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       OpenAPIInfo         `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components OpenAPIComponents   `json:"components"`
+	Tags       []map[string]string `json:"tags,omitempty"`
+	schemas    map[reflect.Type]string
+}
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type OpenAPIComponents struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// PathItem key 为小写的请求方法
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                  `json:"operationId"`
+	Summary     string                  `json:"summary,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Parameters  []*Parameter            `json:"parameters,omitempty"`
+	RequestBody *RequestBody            `json:"requestBody,omitempty"`
+	Responses   map[string]*APIResponse `json:"responses"`
+	Security    []map[string][]string   `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type APIResponse struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema JSON Schema，只包含由 Go 类型和 validate 规则能推导出的关键字
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+const (
+	responseSchemaName = "Response"
+	problemSchemaName  = "Problem"
+	bearerAuthName     = "bearerAuth"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	echoPathParam  = regexp.MustCompile(`:([^/]+)`)
+	componentChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+)
+
+// 没有请求体的方法，json 参数不会生成 requestBody
+var methodsWithoutBody = []string{http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions}
+
+// NewOpenAPI 根据已注册路由的请求参数类型生成 OpenAPI 文档，excludePaths 中的路由不会出现在文档中
+func NewOpenAPI(info OpenAPIInfo, routes []*Route, excludePaths ...string) *OpenAPI {
+	doc := &OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    info,
+		Paths:   map[string]PathItem{},
+		Components: OpenAPIComponents{
+			Schemas: map[string]*Schema{
+				responseSchemaName: {
+					Type: "object",
+					Properties: map[string]*Schema{
+						"status":  {Type: "integer", Description: "业务码，成功为 0"},
+						"message": {Type: "string"},
+						"data":    {},
+					},
+					Required: []string{"status", "message", "data"},
+				},
+				problemSchemaName: {
+					Type: "object",
+					Properties: map[string]*Schema{
+						"type":     {Type: "string"},
+						"title":    {Type: "string"},
+						"status":   {Type: "integer"},
+						"detail":   {Type: "string"},
+						"instance": {Type: "string"},
+						"code":     {Type: "integer", Description: "业务码"},
+					},
+					Required: []string{"type", "title", "status", "code"},
+				},
+			},
+			SecuritySchemes: map[string]*SecurityScheme{
+				bearerAuthName: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		schemas: map[reflect.Type]string{},
+	}
+
+	tags := []string{}
+	// 按请求头协商版本的路由，同一方法和路径只能描述一个操作，文档以未携带请求头时使用的最高版本为准
+	headerVersions := map[string][]any{}
+	headerParameters := map[string]*Parameter{}
+	for _, route := range routes {
+		if slices.Contains(excludePaths, route.Path) {
+			continue
+		}
+		for _, tag := range route.tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+
+		path := echoPathParam.ReplaceAllString(route.Path, "{$1}")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		operation := doc.operation(route)
+		if route.VersionByHeader {
+			key := route.Method + " " + path
+			headerVersions[key] = append(headerVersions[key], route.Version)
+			if parameter, ok := headerParameters[key]; ok && compareVersions(route.Version, parameter.Schema.Default.(string)) < 0 {
+				continue
+			}
+			headerParameters[key] = &Parameter{
+				Name:   consts.API_VERSION_HEADER,
+				In:     "header",
+				Schema: &Schema{Type: "string", Default: route.Version},
+			}
+			operation.Parameters = append(operation.Parameters, headerParameters[key])
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = operation
+	}
+	for key, parameter := range headerParameters {
+		parameter.Schema.Enum = headerVersions[key]
+	}
+	for _, tag := range tags {
+		doc.Tags = append(doc.Tags, map[string]string{"name": tag})
+	}
+	return doc
+}
+
+func (doc *OpenAPI) operation(route *Route) *Operation {
+	operation := &Operation{
+		OperationID: operationID(route.Method, route.Path),
+		Summary:     route.summary,
+		Tags:        route.tags,
+		Responses: map[string]*APIResponse{
+			"200": {
+				Description: "成功",
+				Content: map[string]*MediaType{
+					echo.MIMEApplicationJSON: {Schema: &Schema{Ref: componentRef(responseSchemaName)}},
+				},
+			},
+			"default": {
+				Description: "错误，status 为业务码",
+				Content: map[string]*MediaType{
+					echo.MIMEApplicationJSON:            {Schema: &Schema{Ref: componentRef(responseSchemaName)}},
+					response.MIMEApplicationProblemJSON: {Schema: &Schema{Ref: componentRef(problemSchemaName)}},
+				},
+			},
+		},
+	}
+
+	if len(route.roles) > 0 || strings.Contains(route.Middlewares, "JWTAuthMiddleware") {
+		operation.Security = []map[string][]string{{bearerAuthName: {}}}
+	}
+	if len(route.roles) > 0 {
+		operation.Description = "需要角色: " + route.rolesName()
+	}
+
+	if route.RequestType != nil && route.RequestType.Kind() == reflect.Struct {
+		doc.requestParameters(operation, route.Method, route.RequestType)
+	}
+	if route.ResponseType != nil {
+		operation.Responses["200"].Content[echo.MIMEApplicationJSON].Schema = &Schema{
+			AllOf: []*Schema{
+				{Ref: componentRef(responseSchemaName)},
+				{Properties: map[string]*Schema{"data": doc.schemaOf(route.ResponseType)}},
+			},
+		}
+	}
+	return operation
+}
+
+// requestParameters 按字段标签生成参数：query、param/path、header、cookie 标签为对应位置的参数，
+// 其余 json/form 标签的字段组成请求体，包含 *multipart.FileHeader 字段时请求体为 multipart/form-data
+func (doc *OpenAPI) requestParameters(operation *Operation, method string, requestType reflect.Type) {
+	body := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	// 包含文件字段时请求体为 multipart/form-data
+	hasFile := false
+
+	for _, field := range structFields(requestType) {
+		schema := doc.schemaOf(field.Type)
+		required := applyValidation(schema, field.Tag.Get("validate"))
+
+		if in, name, ok := parameterLocation(field); ok {
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Name:     name,
+				In:       in,
+				Required: required || in == "path",
+				Schema:   schema,
+			})
+			continue
+		}
+
+		if slices.Contains(methodsWithoutBody, method) {
+			continue
+		}
+		name := fieldName(field, "json")
+		if name == "" {
+			name = fieldName(field, "form")
+		}
+		if name == "" {
+			continue
+		}
+		if field.Type == fileHeaderType || field.Type == fileHeaderSliceType {
+			hasFile = true
+		}
+		body.Properties[name] = schema
+		if required {
+			body.Required = append(body.Required, name)
+		}
+	}
+
+	if len(body.Properties) > 0 {
+		contentType := echo.MIMEApplicationJSON
+		if hasFile {
+			contentType = echo.MIMEMultipartForm
+		}
+		operation.RequestBody = &RequestBody{
+			Required: len(body.Required) > 0,
+			Content: map[string]*MediaType{
+				contentType: {Schema: body},
+			},
+		}
+	}
+}
+
+func parameterLocation(field reflect.StructField) (string, string, bool) {
+	for _, location := range []struct{ tag, in string }{
+		{"param", "path"},
+		{"path", "path"},
+		{"query", "query"},
+		{"header", "header"},
+		{"cookie", "cookie"},
+	} {
+		if name := fieldName(field, location.tag); name != "" {
+			return location.in, name, true
+		}
+	}
+	return "", "", false
+}
+
+// schemaOf Go 类型对应的 Schema，具名结构体生成到 components 中并返回引用
+func (doc *OpenAPI) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == fileHeaderType.Elem():
+		return &Schema{Type: "string", Format: "binary"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		return &Schema{Ref: componentRef(doc.component(t))}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: doc.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: doc.schemaOf(t.Elem())}
+	case reflect.Struct:
+		return doc.structSchema(t)
+	}
+	return &Schema{}
+}
+
+// component 将具名结构体注册到 components.schemas，返回组件名
+func (doc *OpenAPI) component(t reflect.Type) string {
+	if name, ok := doc.schemas[t]; ok {
+		return name
+	}
+
+	pkgPath := strings.Split(t.PkgPath(), "/")
+	name := componentChars.ReplaceAllString(pkgPath[len(pkgPath)-1]+"."+t.Name(), "_")
+	doc.schemas[t] = name
+	// 先占位，避免自引用的结构体无限递归
+	doc.Components.Schemas[name] = &Schema{}
+	*doc.Components.Schemas[name] = *doc.structSchema(t)
+	return name
+}
+
+func (doc *OpenAPI) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, field := range structFields(t) {
+		name := fieldName(field, "json")
+		if name == "" {
+			name = field.Name
+		}
+		fieldSchema := doc.schemaOf(field.Type)
+		if applyValidation(fieldSchema, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = fieldSchema
+	}
+	return schema
+}
+
+// structFields 结构体的导出字段，匿名嵌入的结构体字段会被展开
+func structFields(t reflect.Type) []reflect.StructField {
+	fields := []reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("json") == "-" {
+			continue
+		}
+		if field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+				fields = append(fields, structFields(embedded)...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// fieldName 字段标签中的名称，没有该标签时返回空字符串
+func fieldName(field reflect.StructField, tag string) string {
+	value, ok := field.Tag.Lookup(tag)
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(value, ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// applyValidation 将 validate 规则转换为 Schema 关键字，返回字段是否必填；
+// dive 之后的规则作用于数组元素
+func applyValidation(schema *Schema, validate string) bool {
+	if validate == "" {
+		return false
+	}
+
+	rules := strings.Split(validate, ",")
+	if i := slices.Index(rules, "dive"); i >= 0 {
+		if schema.Items != nil {
+			applyValidation(schema.Items, strings.Join(rules[i+1:], ","))
+		}
+		rules = rules[:i]
+	}
+
+	required := false
+	for _, rule := range rules {
+		tag, param, _ := strings.Cut(rule, "=")
+		switch tag {
+		case "required":
+			required = true
+		case "min", "gte":
+			setBound(schema, param, &schema.Minimum, &schema.MinLength, &schema.MinItems)
+		case "max", "lte":
+			setBound(schema, param, &schema.Maximum, &schema.MaxLength, &schema.MaxItems)
+		case "len":
+			setBound(schema, param, &schema.Minimum, &schema.MinLength, &schema.MinItems)
+			setBound(schema, param, &schema.Maximum, &schema.MaxLength, &schema.MaxItems)
+		case "gt":
+			setBound(schema, param, &schema.ExclusiveMinimum, nil, nil)
+		case "lt":
+			setBound(schema, param, &schema.ExclusiveMaximum, nil, nil)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				if schema.Type == "integer" || schema.Type == "number" {
+					if number, err := strconv.ParseFloat(value, 64); err == nil {
+						schema.Enum = append(schema.Enum, number)
+						continue
+					}
+				}
+				schema.Enum = append(schema.Enum, value)
+			}
+		case "unique":
+			schema.UniqueItems = true
+		default:
+			if format, ok := validationFormats[tag]; ok {
+				schema.Format = format
+			} else if pattern, ok := validationPatterns[tag]; ok {
+				schema.Pattern = pattern
+			} else if customRule, ok := validation.Lookup(tag); ok {
+				schema.Pattern = customRule.Pattern
+				schema.Format = customRule.Format
+				schema.Description = strings.TrimPrefix(schema.Description+"；"+customRule.Description, "；")
+			}
+		}
+	}
+	return required
+}
+
+// setBound 按 Schema 类型设置数值、字符串长度或数组长度的边界
+func setBound(schema *Schema, param string, number **float64, length **int, items **int) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch schema.Type {
+	case "integer", "number":
+		*number = &value
+	case "string":
+		if length != nil {
+			n := int(value)
+			*length = &n
+		}
+	case "array":
+		if items != nil {
+			n := int(value)
+			*items = &n
+		}
+	}
+}
+
+// validate 规则对应的 format
+var validationFormats = map[string]string{
+	"email":    "email",
+	"url":      "uri",
+	"uri":      "uri",
+	"uuid":     "uuid",
+	"uuid4":    "uuid",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"datetime": "date-time",
+}
+
+// validate 规则对应的正则
+var validationPatterns = map[string]string{
+	"numeric":  `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":   `^[0-9]+$`,
+	"alpha":    `^[a-zA-Z]+$`,
+	"alphanum": `^[a-zA-Z0-9]+$`,
+}
+
+func componentRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// operationID 由请求方法和路径生成，如 GET /tickets/:id -> get_tickets_id
+func operationID(method string, path string) string {
+	id := strings.ToLower(method) + "_" + strings.Trim(componentChars.ReplaceAllString(strings.ReplaceAll(path, ":", ""), "_"), "_")
+	return strings.ReplaceAll(id, "/", "_")
+}
+
+// 文档页面模板和 docs/vendor.sh 下载的页面脚本
+//
+//go:embed docs
+var openAPIUI embed.FS
+
+// 各文档页面依赖的 docs/assets 中的文件
+var openAPIUIAssets = map[string][]string{
+	"swagger": {"swagger-ui-bundle.js", "swagger-ui.css"},
+	"redoc":   {"redoc.standalone.js"},
+}
+
+// RegisterOpenAPI 按配置挂载 OpenAPI 文档和文档页面，需在其他路由注册完成后调用
+func (s *Server) RegisterOpenAPI() {
+	if s.config.App.OpenAPI == nil || !s.config.App.OpenAPI.Enabled {
+		return
+	}
+
+	openAPIConfig := *s.config.App.OpenAPI
+	if openAPIConfig.Path == "" {
+		openAPIConfig.Path = "/openapi.json"
+	}
+	if openAPIConfig.UIPath == "" {
+		openAPIConfig.UIPath = "/docs"
+	}
+	if openAPIConfig.UI == "" {
+		openAPIConfig.UI = "swagger"
+	}
+
+	var (
+		once sync.Once
+		doc  *OpenAPI
+	)
+	s.GET(openAPIConfig.Path, func(ctx *TelecommunicationsContext) error {
+		once.Do(func() {
+			doc = NewOpenAPI(OpenAPIInfo{
+				Title:   openAPIConfig.Title,
+				Version: openAPIConfig.Version,
+			}, s.routes, openAPIConfig.Path, openAPIConfig.UIPath, openAPIConfig.UIPath+"/assets/*")
+		})
+		return ctx.JSON(http.StatusOK, doc)
+	})
+
+	assets, err := fs.Sub(openAPIUI, "docs/assets")
+	s.Terminate(err != nil, "OpenAPI 文档页面资源错误")
+	missing := missingUIAssets(assets, openAPIConfig.UI)
+	s.Terminate(len(missing) > 0, "OpenAPI 文档页面资源未下载，执行 make docs-assets 后提交 http/docs/assets: "+strings.Join(missing, ", "))
+
+	page, err := renderOpenAPIUI(&openAPIConfig)
+	s.Terminate(err != nil, "OpenAPI 文档页面模板错误")
+	s.GET(openAPIConfig.UIPath, func(ctx *TelecommunicationsContext) error {
+		return ctx.HTMLBlob(http.StatusOK, page)
+	})
+	assetsHandler := echo.StaticDirectoryHandler(assets, false)
+	s.GET(openAPIConfig.UIPath+"/assets/*", func(ctx *TelecommunicationsContext) error {
+		return assetsHandler(ctx)
+	})
+}
+
+// missingUIAssets 文档页面依赖但未下载的文件
+func missingUIAssets(assets fs.FS, ui string) []string {
+	var missing []string
+	for _, name := range openAPIUIAssets[ui] {
+		if _, err := fs.Stat(assets, name); err != nil {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+func renderOpenAPIUI(openAPIConfig *config.OpenAPIConfig) ([]byte, error) {
+	tmpl, err := template.ParseFS(openAPIUI, "docs/"+openAPIConfig.UI+".html")
+	if err != nil {
+		return nil, err
+	}
+	buffer := &bytes.Buffer{}
+	err = tmpl.Execute(buffer, map[string]string{
+		"Title":      openAPIConfig.Title,
+		"SpecPath":   openAPIConfig.Path,
+		"AssetsPath": openAPIConfig.UIPath + "/assets",
+	})
+	return buffer.Bytes(), err
+}
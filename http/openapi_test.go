@@ -0,0 +1,104 @@
+package http
+
+import (
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOpenAPI(t *testing.T) {
+	routes := []*Route{
+		(&Route{Method: http.MethodGet, Path: "/tickets", RequestType: reflect.TypeOf(ListTicketRequest{})}).
+			Roles(models.UserRoleEndUser).Tags("工单"),
+		{Method: http.MethodPost, Path: "/tickets/:id/transitions", RequestType: reflect.TypeOf(TransitTicketRequest{})},
+		{Method: http.MethodPut, Path: "/users/:id/regions", RequestType: reflect.TypeOf(AssignRegionsRequest{})},
+		{Method: http.MethodGet, Path: "/tickets/:id", ResponseType: reflect.TypeOf(&models.RepairTicket{})},
+		{Method: http.MethodGet, Path: "/docs"},
+	}
+
+	doc := NewOpenAPI(OpenAPIInfo{Title: "test", Version: "1"}, routes, "/docs")
+	assert.Equal(t, "3.1.0", doc.OpenAPI)
+	assert.NotContains(t, doc.Paths, "/docs")
+
+	list := doc.Paths["/tickets"]["get"]
+	assert.NotNil(t, list)
+	assert.Equal(t, []string{"工单"}, list.Tags)
+	assert.NotEmpty(t, list.Security)
+	assert.Nil(t, list.RequestBody)
+	assert.Len(t, list.Parameters, 3)
+	size := list.Parameters[2]
+	assert.Equal(t, "size", size.Name)
+	assert.Equal(t, "query", size.In)
+	assert.Equal(t, float64(1), *size.Schema.Minimum)
+	assert.Equal(t, float64(100), *size.Schema.Maximum)
+	assert.Len(t, list.Parameters[0].Schema.Enum, 8)
+
+	transit := doc.Paths["/tickets/{id}/transitions"]["post"]
+	assert.NotNil(t, transit)
+	assert.Equal(t, "path", transit.Parameters[0].In)
+	assert.True(t, transit.Parameters[0].Required)
+	body := transit.RequestBody.Content["application/json"].Schema
+	assert.Equal(t, []string{"status"}, body.Required)
+	assert.Equal(t, 500, *body.Properties["remark"].MaxLength)
+
+	assign := doc.Paths["/users/{id}/regions"]["put"].RequestBody.Content["application/json"].Schema
+	regionIDs := assign.Properties["region_ids"]
+	assert.Equal(t, "array", regionIDs.Type)
+	assert.True(t, regionIDs.UniqueItems)
+	assert.Equal(t, 1, *regionIDs.MinItems)
+	assert.Equal(t, float64(1), *regionIDs.Items.Minimum)
+
+	detail := doc.Paths["/tickets/{id}"]["get"].Responses["200"].Content["application/json"].Schema
+	assert.Len(t, detail.AllOf, 2)
+	assert.Equal(t, "#/components/schemas/models.RepairTicket", detail.AllOf[1].Properties["data"].Ref)
+	assert.Contains(t, doc.Components.Schemas, "models.RepairTicket")
+
+	_, err := json.Marshal(doc)
+	assert.NoError(t, err)
+}
+
+func TestOpenAPI_ComponentSchema(t *testing.T) {
+	doc := NewOpenAPI(OpenAPIInfo{}, nil)
+	schema := doc.schemaOf(reflect.TypeOf(&models.Region{}))
+	assert.Equal(t, "#/components/schemas/models.Region", schema.Ref)
+
+	region := doc.Components.Schemas["models.Region"]
+	assert.Equal(t, "object", region.Type)
+	assert.Equal(t, "date-time", region.Properties["CreatedAt"].Format)
+	// 自引用的 Children 使用引用，避免无限递归
+	assert.Equal(t, "#/components/schemas/models.Region", region.Properties["Children"].Items.Ref)
+}
+
+func TestRenderOpenAPIUI(t *testing.T) {
+	for _, ui := range []string{"swagger", "redoc"} {
+		page, err := renderOpenAPIUI(&config.OpenAPIConfig{UI: ui, Path: "/openapi.json", UIPath: "/docs", Title: "API"})
+		assert.NoError(t, err)
+		assert.Contains(t, string(page), "/openapi.json")
+		assert.Contains(t, string(page), `src="/docs/assets/`)
+		assert.NotContains(t, string(page), "https://")
+	}
+}
+
+// 文档页面的脚本随二进制嵌入，未执行 make docs-assets 时服务无法启动
+func TestOpenAPIUIAssets_Vendored(t *testing.T) {
+	assets, err := fs.Sub(openAPIUI, "docs/assets")
+	require.NoError(t, err)
+	for ui := range openAPIUIAssets {
+		assert.Empty(t, missingUIAssets(assets, ui), ui)
+	}
+}
+
+func TestMissingUIAssets(t *testing.T) {
+	assets := fstest.MapFS{"redoc.standalone.js": {Data: []byte("redoc")}}
+	assert.Empty(t, missingUIAssets(assets, "redoc"))
+	assert.Equal(t, []string{"swagger-ui-bundle.js", "swagger-ui.css"}, missingUIAssets(assets, "swagger"))
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"errors"
+	"telecommunications_repair_hub/models"
+	"telecommunications_repair_hub/models/query"
+	"telecommunications_repair_hub/pkg"
+	"telecommunications_repair_hub/pkg/db"
+
+	"gorm.io/gorm"
+)
+
+// CreateRegionRequest 新建区域，省级区域不需要上级区域
+type CreateRegionRequest struct {
+	ParentID int                `json:"parent_id" validate:"omitempty,min=1"`
+	Name     string             `json:"name" validate:"required,max=64"`
+	Code     string             `json:"code" validate:"required,alphanum,max=32"`
+	Level    models.RegionLevel `json:"level" validate:"required,min=1,max=4"`
+}
+
+// UserRegionsRequest 查询用户负责的区域
+type UserRegionsRequest struct {
+	ID int `param:"id" validate:"required,min=1"`
+}
+
+// AssignRegionsRequest 设置区域管理员负责的区域，会覆盖原有的区域
+type AssignRegionsRequest struct {
+	ID        int   `param:"id" validate:"required,min=1"`
+	RegionIDs []int `json:"region_ids" validate:"required,min=1,unique,dive,min=1"`
+}
+
+func (r *BaseRouter) RegisterRegionRoutes() {
+	r.GET("/regions/tree", regionTree).Roles(models.UserRoleEndUser).Tags("区域").Summary("区域树")
+	r.POST("/regions", createRegion).Roles(models.UserRoleCityAdmin).Tags("区域").Summary("新建区域")
+
+	r.GET("/me/regions", myRegions).Roles(models.UserRoleAreaMgr).Tags("区域").Summary("当前用户负责的区域")
+	r.GET("/users/:id/regions", userRegions).Roles(models.UserRoleCityAdmin).Tags("区域").Summary("用户负责的区域")
+	r.PUT("/users/:id/regions", assignRegions).Roles(models.UserRoleCityAdmin).Tags("区域").Summary("设置区域管理员负责的区域")
+}
+
+func regionTree(ctx *TelecommunicationsContext) ([]*models.Region, error) {
+	q := query.Use(ctx.DBInstance.DB).Region
+	regions, err := q.WithContext(ctx.Request().Context()).Order(q.Level, q.ID).Find()
+	if err != nil {
+		return nil, err
+	}
+	return models.BuildRegionTree(regions), nil
+}
+
+func createRegion(ctx *TelecommunicationsContext, request *CreateRegionRequest) (*models.Region, error) {
+	var parent *models.Region
+	if request.ParentID > 0 {
+		var err error
+		if parent, err = findRegion(ctx, request.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 省级区域没有上级区域，其余区域必须挂在上一级区域下
+	if (parent == nil && request.Level != models.RegionLevelProvince) ||
+		(parent != nil && request.Level != parent.Level+1) {
+		return nil, pkg.ErrParamError.Wrapf("区域层级 %d 与上级区域不匹配", request.Level)
+	}
+
+	region := &models.Region{
+		Name:  request.Name,
+		Code:  request.Code,
+		Level: request.Level,
+	}
+	if parent != nil {
+		region.ParentID = &parent.ID
+	}
+
+	// 区域编码由唯一索引保证不重复，并发创建相同编码时只有一个成功
+	err := query.Use(ctx.DBInstance.DB).Transaction(func(tx *query.Query) error {
+		do := tx.Region.WithContext(ctx.Request().Context())
+		if err := do.Create(region); err != nil {
+			return err
+		}
+		region.BuildPath(parent)
+		return do.Save(region)
+	})
+	if db.IsUniqueViolation(err) {
+		return nil, pkg.ErrParamError.Wrapf("区域编码 %s 已存在", request.Code)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return region, nil
+}
+
+func myRegions(ctx *TelecommunicationsContext) ([]*models.Region, error) {
+	return findUserRegions(ctx, ctx.User.UserID)
+}
+
+func userRegions(ctx *TelecommunicationsContext, request *UserRegionsRequest) ([]*models.Region, error) {
+	return findUserRegions(ctx, request.ID)
+}
+
+func assignRegions(ctx *TelecommunicationsContext, request *AssignRegionsRequest) ([]*models.Region, error) {
+	q := query.Use(ctx.DBInstance.DB)
+	c := ctx.Request().Context()
+
+	user, err := q.User.WithContext(c).Where(q.User.ID.Eq(request.ID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, pkg.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != models.UserRoleAreaMgr {
+		return nil, pkg.ErrParamError.Wrapf("用户 %d 不是区域管理员", user.ID)
+	}
+
+	count, err := q.Region.WithContext(c).Where(q.Region.ID.In(request.RegionIDs...)).Count()
+	if err != nil {
+		return nil, err
+	}
+	if int(count) != len(request.RegionIDs) {
+		return nil, pkg.ErrRegionNotFound
+	}
+
+	err = q.Transaction(func(tx *query.Query) error {
+		if _, err := tx.UserRegion.WithContext(c).Where(tx.UserRegion.UserID.Eq(user.ID)).Delete(); err != nil {
+			return err
+		}
+		userRegions := make([]*models.UserRegion, 0, len(request.RegionIDs))
+		for _, regionID := range request.RegionIDs {
+			userRegions = append(userRegions, &models.UserRegion{UserID: user.ID, RegionID: regionID})
+		}
+		return tx.UserRegion.WithContext(c).Create(userRegions...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return findUserRegions(ctx, user.ID)
+}
+
+func findUserRegions(ctx *TelecommunicationsContext, userID int) ([]*models.Region, error) {
+	q := query.Use(ctx.DBInstance.DB)
+	r, ur := q.Region, q.UserRegion
+
+	regions, err := r.WithContext(ctx.Request().Context()).
+		Join(ur, ur.RegionID.EqCol(r.ID)).
+		Where(ur.UserID.Eq(userID)).
+		Order(r.Level, r.ID).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
+
+func findRegion(ctx *TelecommunicationsContext, id int) (*models.Region, error) {
+	q := query.Use(ctx.DBInstance.DB).Region
+	region, err := q.WithContext(ctx.Request().Context()).Where(q.ID.Eq(id)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, pkg.ErrRegionNotFound
+	}
+	return region, err
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"reflect"
+	"strings"
+	"telecommunications_repair_hub/consts"
+	"telecommunications_repair_hub/models"
+	"telecommunications_repair_hub/pkg"
+	"telecommunications_repair_hub/pkg/auth"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Route 通过 Server.Add 注册的路由信息，用于权限控制和启动时的路由表
+type Route struct {
+	Method      string
+	Path        string
+	HandlerName string
+	Middlewares string
+	// 处理函数的请求参数类型，没有请求参数时为 nil
+	RequestType reflect.Type
+	// 处理函数返回 (T, error) 时为 T 的类型，作为统一响应结构中 data 的类型
+	ResponseType reflect.Type
+	// 通过 Group.Version / Group.HeaderVersion 注册时的接口版本
+	Version string
+	// 是否按请求头协商版本，为 false 时版本体现在路径中
+	VersionByHeader bool
+
+	roles   []models.UserRole
+	summary string
+	tags    []string
+	server  *Server
+}
+
+// Summary 路由说明，用于生成 OpenAPI 文档
+func (r *Route) Summary(summary string) *Route {
+	r.summary = summary
+	return r
+}
+
+// Tags 路由分组标签，用于生成 OpenAPI 文档
+func (r *Route) Tags(tags ...string) *Route {
+	r.tags = append(r.tags, tags...)
+	return r
+}
+
+// Roles 声明允许访问该路由的角色，角色按等级继承：
+// 总管理员 ⊇ 区域管理员 ⊇ 终端用户，因此 Roles(models.UserRoleAreaMgr) 也允许总管理员访问。
+// 声明了角色的路由会自动校验访问 token，无需再挂载 JWTAuthMiddleware。
+func (r *Route) Roles(roles ...models.UserRole) *Route {
+	r.roles = append(r.roles, roles...)
+	return r
+}
+
+// BodyLimit 设置路由的请求体大小上限，如 "90M"，设置后该路由不再使用全局的请求体限制，
+// limit 为空时不限制
+func (r *Route) BodyLimit(limit string) *Route {
+	routeLimit := func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	if limit != "" {
+		routeLimit = middleware.BodyLimit(limit)
+	}
+	r.server.bodyLimits[r.Method+" "+r.Path] = routeLimit
+	return r
+}
+
+// GetRoles 允许访问该路由的角色
+func (r *Route) GetRoles() []models.UserRole {
+	return r.roles
+}
+
+func (r *Route) versionName() string {
+	if r.Version != "" && r.VersionByHeader {
+		return r.Version + " (" + consts.API_VERSION_HEADER + ")"
+	}
+	return r.Version
+}
+
+func (r *Route) rolesName() string {
+	roles := make([]string, 0, len(r.roles))
+	for _, role := range r.roles {
+		roles = append(roles, role.String())
+	}
+	return strings.Join(roles, ",")
+}
+
+// authorize 校验当前用户是否拥有访问路由所需的角色，
+// 未登录返回 pkg.ErrInvalidToken，角色不满足返回 pkg.ErrNoPermission
+func (r *Route) authorize(ctx echo.Context) error {
+	if len(r.roles) == 0 {
+		return nil
+	}
+
+	claims, ok := ctx.Get(consts.CONTEXT_USER_KEY).(*auth.Claims)
+	if !ok {
+		var err error
+		if claims, err = authenticate(ctx); err != nil {
+			return err
+		}
+		ctx.Set(consts.CONTEXT_USER_KEY, claims)
+	}
+
+	for _, role := range r.roles {
+		if claims.Role.Includes(role) {
+			return nil
+		}
+	}
+	return pkg.ErrNoPermission
+}
@@ -4,11 +4,17 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"slices"
 	"strconv"
+	"sync"
+	"telecommunications_repair_hub/models"
+	"telecommunications_repair_hub/models/query"
+	"telecommunications_repair_hub/pkg/auth"
 	"telecommunications_repair_hub/pkg/network_traffic"
 	"telecommunications_repair_hub/pkg/response"
 
+	"github.com/labstack/echo/v4"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -22,25 +28,16 @@ func NewBaseRouter(e *Server) *BaseRouter {
 	}
 }
 
-type HealthRequest struct {
-	Message string `json:"message" query:"message" validate:"required,min=3,max=100"`
-}
-
 // UserRequest 用户注册请求示例，展示更多验证规则
 type UserRequest struct {
 	Name     string `json:"name" validate:"required,min=2,max=50"`
 	Email    string `json:"email" validate:"required,email"`
 	Age      int    `json:"age" validate:"required,min=18,max=120"`
-	Phone    string `json:"phone" validate:"required,numeric,len=11"`
+	Phone    string `json:"phone" validate:"required,mobile"`
 	Username string `json:"username" validate:"required,alphanum,min=3,max=20"`
 }
 
 func (r *BaseRouter) RegisterRoutes() {
-	r.GET("/health", func(ctx *TelecommunicationsContext, request *HealthRequest) error {
-		fmt.Println(request.Message)
-		return response.NewResponse(ctx.Context).Success(request)
-	})
-
 	// 用户注册示例端点
 	r.POST("/register", func(ctx *TelecommunicationsContext, request *UserRequest) error {
 		fmt.Printf("注册用户: %+v\n", request)
@@ -50,6 +47,24 @@ func (r *BaseRouter) RegisterRoutes() {
 		})
 	})
 
+	// 当前登录用户信息
+	r.GET("/me", func(ctx *TelecommunicationsContext) (*auth.Claims, error) {
+		return ctx.User, nil
+	}, JWTAuthMiddleware)
+
+	// 用户列表，仅总管理员可访问
+	r.GET("/users", func(ctx *TelecommunicationsContext) error {
+		users, err := query.Use(ctx.DBInstance.DB).User.WithContext(ctx.Request().Context()).Find()
+		if err != nil {
+			return response.NewResponse(ctx.Context).Error(err)
+		}
+		return response.NewResponse(ctx.Context).Success(users)
+	}).Roles(models.UserRoleCityAdmin)
+
+	r.RegisterHealthRoutes()
+	r.RegisterRegionRoutes()
+	r.RegisterTicketRoutes()
+
 	r.GET("/test", func(ctx *TelecommunicationsContext) error {
 		return response.NewResponse(ctx.Context).Success(map[string]interface{}{
 			"message": "测试成功",
@@ -65,20 +80,24 @@ func (r *BaseRouter) RegisterRoutes() {
 		return nil
 	})
 
-	if _, err := os.Stat("test-network-traffic"); os.IsNotExist(err) {
-		// 使用dd生成100M的文件
-		tempData := slices.Repeat([]byte{'a'}, 100*1024*1024)
-		os.WriteFile("test-network-traffic", tempData, 0644)
-	}
-
-	fd, err := os.OpenFile("test-network-traffic", os.O_RDONLY, 0644)
-	if err != nil {
-		panic(err)
-	}
-
 	var OneMB = 1024 * 1024
 
+	// 测速下载不校验登录，只在开启功能开关时可用，关闭时与未注册的路由一样返回 404
 	r.GET("/test-network-traffic", func(ctx *TelecommunicationsContext) error {
+		if !ctx.Config.FeatureEnabled(featureNetworkTrafficTest) {
+			return echo.ErrNotFound
+		}
+
+		path, err := trafficTestFile()
+		if err != nil {
+			return response.NewResponse(ctx.Context).Error(err)
+		}
+		fd, err := os.Open(path)
+		if err != nil {
+			return response.NewResponse(ctx.Context).Error(err)
+		}
+		defer fd.Close()
+
 		// 获取文件大小
 		fileSize, err := fd.Seek(0, io.SeekEnd)
 		if err != nil {
@@ -91,11 +110,9 @@ func (r *BaseRouter) RegisterRoutes() {
 			return response.NewResponse(ctx.Context).Error(err)
 		}
 
-		networkTraffic := network_traffic.NewNetworkTraffic(
-			10*network_traffic.TrafficLimitUnitMB, // 10MB限制
-			10*network_traffic.TrafficLimitUnitMB, // 10MB限制
-			fd,
-		)
+		// 限速由 app.rateLimit 配置，支持热更新
+		limits := network_traffic.DefaultLimits()
+		networkTraffic := network_traffic.NewNetworkTraffic(limits.Limit, limits.Burst, fd)
 
 		ctx.Response().Header().Set("Content-Length", strconv.Itoa(int(fileSize)))
 		ctx.Response().Header().Set("Content-Type", "application/octet-stream")
@@ -111,3 +128,32 @@ func (r *BaseRouter) RegisterRoutes() {
 		return nil
 	})
 }
+
+// 测速下载接口的功能开关，默认关闭
+const featureNetworkTrafficTest = "networkTrafficTest"
+
+// 测速文件大小
+const trafficTestFileSize = 100 * 1024 * 1024
+
+var (
+	trafficTestFileOnce sync.Once
+	trafficTestFilePath string
+	trafficTestFileErr  error
+)
+
+// trafficTestFile 第一次下载时在临时目录生成测速文件，注册路由时不写文件
+func trafficTestFile() (string, error) {
+	trafficTestFileOnce.Do(func() {
+		path := filepath.Join(os.TempDir(), "test-network-traffic")
+		if info, err := os.Stat(path); err == nil && info.Size() == trafficTestFileSize {
+			trafficTestFilePath = path
+			return
+		}
+		if err := os.WriteFile(path, slices.Repeat([]byte{'a'}, trafficTestFileSize), 0644); err != nil {
+			trafficTestFileErr = err
+			return
+		}
+		trafficTestFilePath = path
+	})
+	return trafficTestFilePath, trafficTestFileErr
+}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/pkg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterRoutes_NetworkTrafficDisabled(t *testing.T) {
+	s := newTestServer()
+	s.config.App.Upload = &config.UploadConfig{Dir: t.TempDir(), MaxSize: 10}
+	NewBaseRouter(s).RegisterRoutes()
+
+	// 未开启功能开关时测速下载与未注册的路由一样返回 404
+	rec, body := serve(s, http.MethodGet, "/test-network-traffic", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, float64(pkg.ErrNotFound.Code), body["status"])
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
@@ -8,18 +9,26 @@ import (
 	"os"
 	"reflect"
 	"runtime"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/consts"
+	"telecommunications_repair_hub/models"
+	"telecommunications_repair_hub/models/query"
 	"telecommunications_repair_hub/pkg"
+	"telecommunications_repair_hub/pkg/auth"
 	"telecommunications_repair_hub/pkg/db"
+	"telecommunications_repair_hub/pkg/health"
 	"telecommunications_repair_hub/pkg/response"
+	"telecommunications_repair_hub/pkg/validation"
 
 	"github.com/fatih/color"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
 )
 
 type Server struct {
@@ -28,51 +37,28 @@ type Server struct {
 	db                    *db.DB
 	globalMiddlewares     map[string]echo.MiddlewareFunc
 	globalMiddlewaresName string
+	routes                []*Route
+	// 按请求头协商版本的路由，key 为 "方法 路径"
+	versionedRoutes map[string]*versionedRoute
+	// /livez、/readyz 使用的健康检查注册表
+	health *health.Registry
+	// 热更新后的配置，未热更新时为空
+	reloaded atomic.Pointer[config.Config]
+	cors     *dynamicCORS
+	// 通过 Route.BodyLimit 设置的路由级请求体限制，key 为 "方法 路径"
+	bodyLimits map[string]echo.MiddlewareFunc
 }
 
-type Validator struct {
-	validator *validator.Validate
-}
+// 未通过 Route.BodyLimit 设置限制的路由使用的请求体大小上限
+const defaultBodyLimit = "5M"
 
-type ValidationErrors struct {
-	Errors []validator.FieldError `json:"errors"`
-}
-
-func (v *ValidationErrors) Error() string {
-	errors := []string{}
-	for _, error := range v.Errors {
-		errors = append(errors, error.Error())
-	}
-	return strings.Join(errors, ", ")
-}
-
-func (v *Validator) Validate(i interface{}) error {
-	err := v.validator.Struct(i)
-	if err == nil {
-		return nil
-	}
-	// 注释掉调试输出，如果需要可以使用正式的日志记录
-	// utils.PP("err", err)
-	// 处理验证错误
-	if validatorErrors, ok := err.(validator.ValidationErrors); ok {
-		return &ValidationErrors{
-			Errors: validatorErrors,
-		}
-	}
-
-	return err
-}
-
-func NewServer(config *config.Config) *Server {
-	dbInstance, err := db.New(config)
-	if err != nil {
-		panic(err)
-	}
+// NewServer 创建 Server，数据库连接由调用方创建和关闭
+func NewServer(config *config.Config, dbInstance *db.DB) *Server {
+	registerHealthChecks(config, dbInstance)
 
 	e := echo.New()
-	e.Validator = &Validator{
-		validator: validator.New(),
-	}
+	e.Validator = NewValidator()
+	e.Binder = &Binder{}
 	e.HideBanner = true
 	e.HidePort = true
 	s := &Server{
@@ -80,6 +66,13 @@ func NewServer(config *config.Config) *Server {
 		config:            config,
 		db:                dbInstance,
 		globalMiddlewares: make(map[string]echo.MiddlewareFunc),
+		health:            health.Default,
+		bodyLimits:        make(map[string]echo.MiddlewareFunc),
+	}
+	if corsConfig := config.GetCORSConfig(); corsConfig != nil {
+		s.cors = newDynamicCORS(corsConfig.AllowOrigins)
+	} else {
+		s.cors = newDynamicCORS(nil)
 	}
 
 	s.UseGlobalMiddleware()
@@ -89,8 +82,8 @@ func NewServer(config *config.Config) *Server {
 
 func (s *Server) UseGlobalMiddleware() {
 	useMiddlewares := map[string]echo.MiddlewareFunc{
-		"cors":      middleware.CORS(),
-		"bodyLimit": middleware.BodyLimit("5M"),
+		"cors":      s.cors.middleware(),
+		"bodyLimit": s.bodyLimitMiddleware(),
 		"secure":    middleware.Secure(),
 		"recover": middleware.RecoverWithConfig(middleware.RecoverConfig{
 			DisableStackAll:   true,
@@ -101,8 +94,11 @@ func (s *Server) UseGlobalMiddleware() {
 			Format:           fmt.Sprintf(`%s time":"%s","method":"%s","uri":"%s","status":%s,"latency_human":"%s","bytes_in":%s,"bytes_out":%s}`, color.BlueString("[TelecommunicationsServer]"), color.GreenString("${time_custom}"), color.GreenString("${method}"), color.GreenString("${uri}"), color.GreenString("${status}"), color.GreenString("${latency_human}"), color.GreenString("${bytes_in}"), color.GreenString("${bytes_out}")) + "\n",
 			CustomTimeFormat: "2006-01-02 15:04:05",
 		}),
+		"requestID":      RequestIDMiddleware(),
+		"sqlTrace":       SQLTraceMiddleware(s.db),
 		"requestCounter": RequestCounterMiddleware,
-	}	
+		"response":       response.Middleware(s.responseOptions()),
+	}
 	userMiddlewaresName := ""
 	for name, middleware := range useMiddlewares {
 		s.globalMiddlewares[name] = middleware
@@ -113,39 +109,93 @@ func (s *Server) UseGlobalMiddleware() {
 	s.globalMiddlewaresName = userMiddlewaresName
 }
 
+// bodyLimitMiddleware 限制请求体大小，设置了路由级限制的路由使用路由的限制，其余路由使用 defaultBodyLimit
+func (s *Server) bodyLimitMiddleware() echo.MiddlewareFunc {
+	global := middleware.BodyLimit(defaultBodyLimit)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		limited := global(next)
+		return func(c echo.Context) error {
+			if routeLimit, ok := s.bodyLimits[c.Request().Method+" "+c.Path()]; ok {
+				return routeLimit(next)(c)
+			}
+			return limited(c)
+		}
+	}
+}
+
+// responseOptions 从配置读取响应模式，未配置时使用 response.DefaultOptions
+func (s *Server) responseOptions() response.Options {
+	options := response.DefaultOptions
+	if responseConfig := s.config.App.Response; responseConfig != nil {
+		if responseConfig.Mode != "" {
+			options.Mode = response.Mode(responseConfig.Mode)
+		}
+		if responseConfig.Format != "" {
+			options.Format = response.Format(responseConfig.Format)
+		}
+	}
+	return options
+}
+
 type TelecommunicationsContext struct {
 	echo.Context
 	DBInstance *db.DB
+	Config     *config.Config
+	// 当前登录用户，仅在路由挂载了 JWTAuthMiddleware 时有值
+	User *auth.Claims
+}
+
+// IsAuthenticated 当前请求是否携带了有效的访问 token
+func (c *TelecommunicationsContext) IsAuthenticated() bool {
+	return c.User != nil
+}
+
+// RegionScope 按当前用户的角色限定查询的区域范围：总管理员不限制，
+// 区域管理员只能看到负责区域及其下级区域的数据，其他用户看不到任何数据
+func (c *TelecommunicationsContext) RegionScope(column field.Int) (func(gen.Dao) gen.Dao, error) {
+	if c.User != nil && c.User.Role.Includes(models.UserRoleCityAdmin) {
+		return func(dao gen.Dao) gen.Dao { return dao }, nil
+	}
+
+	regionIDs := []int{}
+	if c.User != nil && c.User.Role.Includes(models.UserRoleAreaMgr) {
+		var err error
+		regionIDs, err = query.Use(c.DBInstance.DB).ManagedRegionIDs(c.Request().Context(), c.User.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return query.RegionScope(column, regionIDs), nil
 }
 
 type HttpHandler func(ctx *TelecommunicationsContext, request any) error
 
-func (s *Server) GET(path string, handler any, middlewares ...echo.MiddlewareFunc) {
-	s.Add(http.MethodGet, path, handler, middlewares...)
+func (s *Server) GET(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return s.Add(http.MethodGet, path, handler, middlewares...)
 }
 
-func (s *Server) POST(path string, handler any, middlewares ...echo.MiddlewareFunc) {
-	s.Add(http.MethodPost, path, handler, middlewares...)
+func (s *Server) POST(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return s.Add(http.MethodPost, path, handler, middlewares...)
 }
 
-func (s *Server) PUT(path string, handler any, middlewares ...echo.MiddlewareFunc) {
-	s.Add(http.MethodPut, path, handler, middlewares...)
+func (s *Server) PUT(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return s.Add(http.MethodPut, path, handler, middlewares...)
 }
 
-func (s *Server) PATCH(path string, handler any, middlewares ...echo.MiddlewareFunc) {
-	s.Add(http.MethodPatch, path, handler, middlewares...)
+func (s *Server) PATCH(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return s.Add(http.MethodPatch, path, handler, middlewares...)
 }
 
-func (s *Server) OPTIONS(path string, handler any, middlewares ...echo.MiddlewareFunc) {
-	s.Add(http.MethodOptions, path, handler, middlewares...)
+func (s *Server) OPTIONS(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return s.Add(http.MethodOptions, path, handler, middlewares...)
 }
 
-func (s *Server) HEAD(path string, handler any, middlewares ...echo.MiddlewareFunc) {
-	s.Add(http.MethodHead, path, handler, middlewares...)
+func (s *Server) HEAD(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return s.Add(http.MethodHead, path, handler, middlewares...)
 }
 
-func (s *Server) DELETE(path string, handler any, middlewares ...echo.MiddlewareFunc) {
-	s.Add(http.MethodDelete, path, handler, middlewares...)
+func (s *Server) DELETE(path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return s.Add(http.MethodDelete, path, handler, middlewares...)
 }
 
 // 终止如果不想等
@@ -156,67 +206,159 @@ func (s *Server) Terminate(condition bool, message string) {
 	}
 }
 
-func (s *Server) RequestValidator(ctx echo.Context, requestType any, request reflect.Type) error {
+// RequestValidator 校验请求参数，校验失败时写入参数错误响应，
+// data 为全部未通过校验的字段，提示语言由 Accept-Language 决定
+func (s *Server) RequestValidator(ctx echo.Context, requestType any) error {
 	if s.Validator != nil {
 		if err := ctx.Validate(requestType); err != nil {
 			if validationErrors, ok := err.(*ValidationErrors); ok {
-				firstError := validationErrors.Errors[0]
-				fieldType := firstError.StructField()
-				actualTag := firstError.ActualTag()
-
-				validationFieldTag, validationRule := getValidationFieldTag(request, fieldType, actualTag)
-
-				err = fmt.Errorf("参数 %s 无法通过 %s 规则的验证", validationFieldTag, validationRule)
-				response.NewResponse(ctx).SetStatus(pkg.GetTeleCommunicationErrorCode(pkg.ErrParamError)).
-					SetMessage(pkg.ErrParamError.Error()).
-					Error(err)
-				return err
+				err = validationErrors.Translate(requestLanguage(ctx))
 			}
 
-			response.NewResponse(ctx).
-				SetStatus(pkg.GetTeleCommunicationErrorCode(pkg.ErrParamError)).
-				SetMessage(pkg.ErrParamError.Error()).Error(err)
+			errorResponse(ctx, pkg.ErrParamError.Wrap(err))
 			return err
 		}
 	}
 	return nil
 }
 
-func (s *Server) ResoverHandler(ctx echo.Context, handlerValue reflect.Value, requests ...any) error {
-	context := &TelecommunicationsContext{
-		Context:    ctx,
-		DBInstance: s.db,
+// errorResponse 将错误映射为已注册的业务错误并返回统一的响应结构，
+// 未注册的错误按 pkg.ErrInternal 返回，Data 为错误详情
+func errorResponse(ctx echo.Context, err error) error {
+	teleErr := toTeleCommunicationError(err)
+	language := requestLanguage(ctx)
+
+	detail := err.Error()
+	var data any
+	if e, ok := err.(*pkg.TeleCommunicationError); ok {
+		if cause := e.Cause(); cause != nil {
+			detail = cause.Error()
+			// 参数校验错误以字段列表作为 data 返回
+			if fieldErrors, ok := cause.(FieldErrors); ok {
+				data = fieldErrors
+			}
+		} else {
+			detail = e.Localize(language)
+		}
 	}
 
+	return response.NewResponse(ctx).
+		SetHTTPStatus(teleErr.HTTPStatus).
+		SetStatus(teleErr.Code).
+		SetMessage(teleErr.Localize(language)).
+		SetData(data).
+		Error(errors.New(detail))
+}
+
+// toTeleCommunicationError 查找错误对应的业务错误，echo 框架返回的 HTTP 错误按状态码映射
+func toTeleCommunicationError(err error) *pkg.TeleCommunicationError {
+	if e, ok := pkg.Lookup(err); ok {
+		return e
+	}
+
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		switch httpError.Code {
+		case http.StatusBadRequest:
+			return pkg.ErrParamError
+		case http.StatusUnauthorized:
+			return pkg.ErrInvalidToken
+		case http.StatusForbidden:
+			return pkg.ErrNoPermission
+		case http.StatusNotFound:
+			return pkg.ErrNotFound
+		case http.StatusMethodNotAllowed:
+			return pkg.ErrMethodNotAllowed
+		case http.StatusRequestEntityTooLarge:
+			return pkg.ErrRequestTooLarge
+		}
+	}
+	return pkg.ErrInternal
+}
+
+func (s *Server) ResoverHandler(ctx echo.Context, handlerValue reflect.Value, requests ...any) error {
 	in := []reflect.Value{
-		reflect.ValueOf(context),
+		reflect.ValueOf(s.newContext(ctx)),
 	}
 	if len(requests) > 0 {
 		in = append(in, reflect.ValueOf(requests[0]))
 	}
-	result := handlerValue.Call(in)[0]
-	if result.IsNil() {
+	results := handlerValue.Call(in)
+	errResult := results[len(results)-1]
+	if errResult.IsNil() {
+		if len(results) == 2 {
+			return respond(ctx, results[0].Interface(), true, nil)
+		}
+		return respond(ctx, nil, false, nil)
+	}
+	return respond(ctx, nil, false, errResult.Interface().(error))
+}
+
+// ApplyConfig 应用热更新后的配置，处理函数通过 TelecommunicationsContext.Config 读取新配置
+func (s *Server) ApplyConfig(cfg *config.Config) {
+	s.reloaded.Store(cfg)
+	if corsConfig := cfg.GetCORSConfig(); corsConfig != nil {
+		s.cors.SetOrigins(corsConfig.AllowOrigins)
+	}
+}
+
+// currentConfig 当前生效的配置
+func (s *Server) currentConfig() *config.Config {
+	if cfg := s.reloaded.Load(); cfg != nil {
+		return cfg
+	}
+	return s.config
+}
+
+func (s *Server) newContext(ctx echo.Context) *TelecommunicationsContext {
+	context := &TelecommunicationsContext{
+		Context:    ctx,
+		DBInstance: s.db,
+		Config:     s.currentConfig(),
+	}
+	if claims, ok := ctx.Get(consts.CONTEXT_USER_KEY).(*auth.Claims); ok {
+		context.User = claims
+	}
+	return context
+}
+
+// respond 处理函数返回后的统一处理
+func respond(ctx echo.Context, result any, hasResult bool, err error) error {
+	if err == nil {
+		// func(ctx, request) (*Resp, error) 形式的处理函数，由框架写入统一响应结构
+		if hasResult && !ctx.Response().Committed {
+			return response.NewResponse(ctx).Success(result)
+		}
 		return nil
 	}
-	respError := result.Interface().(error)
-	if respError != nil {
-		slog.Error("API返回数据异常", "error", respError)
+	if ctx.Response().Committed {
+		slog.Error("API返回数据异常", "error", err)
+		return nil
 	}
 
-	return nil
+	// 处理函数未写入响应时交给 HTTPErrorHandler 按业务错误返回
+	return err
 }
 
-func (s *Server) Add(method string, path string, handler any, middlewares ...echo.MiddlewareFunc) {
-	handlerValue := reflect.ValueOf(handler)
-	s.Terminate(handlerValue.Kind() != reflect.Func, "处理函数必须是一个函数")
+// handlerBuilder 根据路由生成 echo 处理函数，同时补充路由的处理函数名和请求、响应类型
+type handlerBuilder func(s *Server, route *Route) echo.HandlerFunc
 
-	handlerType := handlerValue.Type()
+func (s *Server) Add(method string, path string, handler any, middlewares ...echo.MiddlewareFunc) *Route {
+	return s.handle(method, path, middlewares, s.reflectHandler(handler))
+}
 
-	s.Terminate(handlerType.NumIn() < 1, "处理函数必须至少有一个参数")
-	s.Terminate(handlerType.In(0) != reflect.TypeOf(&TelecommunicationsContext{}), "处理函数第一个参数必须是TelecommunicationsContext")
-	s.Terminate(handlerType.NumOut() != 1 || handlerType.Out(0) != reflect.TypeOf((*error)(nil)).Elem(), "处理函数必须返回一个error")
+// handle 记录路由信息并向 echo 注册
+func (s *Server) handle(method string, path string, middlewares []echo.MiddlewareFunc, build handlerBuilder) *Route {
+	_, versioned := s.versionedRoutes[method+" "+path]
+	s.Terminate(versioned, "已按请求头注册版本的接口，不能再注册不区分版本的处理函数: "+method+" "+path)
 
-	inputNumber := handlerType.NumIn()
+	route := s.newRoute(method, path, middlewares)
+	s.Echo.Add(method, path, build(s, route), middlewares...)
+	s.routes = append(s.routes, route)
+	return route
+}
+
+func (s *Server) newRoute(method string, path string, middlewares []echo.MiddlewareFunc) *Route {
 	userMiddlewaresName := s.globalMiddlewaresName
 
 	if len(middlewares) > 0 {
@@ -229,80 +371,72 @@ func (s *Server) Add(method string, path string, handler any, middlewares ...ech
 		userMiddlewaresName = userMiddlewaresName[:len(userMiddlewaresName)-1]
 	}
 
-	handlerName := handlerType.String()
-	addTerminalTable(s.config.App.Port, method, path,
-		handlerName, userMiddlewaresName)
+	return &Route{
+		Method:      method,
+		Path:        path,
+		Middlewares: userMiddlewaresName,
+		server:      s,
+	}
+}
 
-	s.Echo.Add(method, path, func(ctx echo.Context) error {
-		if inputNumber == 1 {
-			return s.ResoverHandler(ctx, handlerValue)
-		}
-		request := handlerType.In(1)
-		if request.Kind() == reflect.Ptr {
-			request = request.Elem()
-		}
+// reflectHandler 校验处理函数，每次请求通过反射创建请求参数并调用处理函数
+func (s *Server) reflectHandler(handler any) handlerBuilder {
+	handlerValue := reflect.ValueOf(handler)
+	s.Terminate(handlerValue.Kind() != reflect.Func, "处理函数必须是一个函数")
 
-		requestType := reflect.New(request).Interface()
+	handlerType := handlerValue.Type()
 
-		if err := ctx.Bind(requestType); err != nil {
-			err := response.NewResponse(ctx).
-				SetStatus(pkg.GetTeleCommunicationErrorCode(pkg.ErrParamError)).
-				SetMessage(pkg.ErrParamError.Error()).
-				Error(err)
+	s.Terminate(handlerType.NumIn() < 1, "处理函数必须至少有一个参数")
+	s.Terminate(handlerType.In(0) != reflect.TypeOf(&TelecommunicationsContext{}), "处理函数第一个参数必须是TelecommunicationsContext")
+	s.Terminate(handlerType.NumIn() > 2, "处理函数最多有两个参数")
+	s.Terminate(handlerType.NumOut() < 1 || handlerType.NumOut() > 2 ||
+		handlerType.Out(handlerType.NumOut()-1) != reflect.TypeOf((*error)(nil)).Elem(), "处理函数必须返回 error 或 (T, error)")
 
-			return err
-		}
+	inputNumber := handlerType.NumIn()
 
-		if err := s.RequestValidator(ctx, requestType, request); err != nil {
-			return err
+	return func(s *Server, route *Route) echo.HandlerFunc {
+		route.HandlerName = handlerType.String()
+		if handlerType.NumOut() == 2 {
+			route.ResponseType = handlerType.Out(0)
+		}
+		if inputNumber > 1 {
+			route.RequestType = handlerType.In(1)
+			if route.RequestType.Kind() == reflect.Ptr {
+				route.RequestType = route.RequestType.Elem()
+			}
 		}
 
-		return s.ResoverHandler(ctx, handlerValue, requestType)
-	}, middlewares...)
-}
+		return func(ctx echo.Context) error {
+			if err := route.authorize(ctx); err != nil {
+				return errorResponse(ctx, err)
+			}
 
-func getValidationFieldTag(structType reflect.Type, defaultTag string, actualTag string) (string, string) {
-	if structType.Kind() == reflect.Ptr {
-		structType = structType.Elem()
-	}
+			if inputNumber == 1 {
+				return s.ResoverHandler(ctx, handlerValue)
+			}
+			request := handlerType.In(1)
+			if request.Kind() == reflect.Ptr {
+				request = request.Elem()
+			}
 
-	structField, _ := structType.FieldByName(defaultTag)
+			requestType := reflect.New(request).Interface()
 
-	validationFieldTag := ""
-	ok := false
-	validationFieldTag, ok = structField.Tag.Lookup("query")
-	if !ok {
-		validationFieldTag, ok = structField.Tag.Lookup("json")
-	}
-	if !ok {
-		validationFieldTag, ok = structField.Tag.Lookup("path")
-	}
-	if !ok {
-		validationFieldTag, ok = structField.Tag.Lookup("form")
-	}
-	if !ok {
-		validationFieldTag, ok = structField.Tag.Lookup("header")
-	}
-	if !ok {
-		validationFieldTag, ok = structField.Tag.Lookup("param")
-	}
+			if err := ctx.Bind(requestType); err != nil {
+				return errorResponse(ctx, pkg.ErrParamError.Wrap(err))
+			}
 
-	if !ok {
-		validationFieldTag = defaultTag
-	}
+			if err := s.RequestValidator(ctx, requestType); err != nil {
+				return err
+			}
 
-	// 获取验证规则
-	validationRule := structField.Tag.Get("validate")
-	// 获取validationRule中的 actualTag
-	validationRules := strings.Split(validationRule, ",")
-	for _, rule := range validationRules {
-		if strings.HasPrefix(rule, actualTag) {
-			validationRule = rule
-			break
+			return s.ResoverHandler(ctx, handlerValue, requestType)
 		}
 	}
+}
 
-	return validationFieldTag, validationRule
+// Routes 已注册的路由
+func (s *Server) Routes() []*Route {
+	return s.routes
 }
 
 func getFuncName(middleware echo.MiddlewareFunc) string {
@@ -315,7 +449,7 @@ func getFuncName(middleware echo.MiddlewareFunc) string {
 	return funcName
 }
 
-func addTerminalTable(port string, method string, path string, handler string, middleware string) {
+func addTerminalTable(port string, method string, path string, handler string, middleware string, roles string, version string) {
 	userMiddlewaresName := middleware
 	tableRouter.AppendRow(table.Row{
 		port,
@@ -323,6 +457,8 @@ func addTerminalTable(port string, method string, path string, handler string, m
 		path,
 		handler,
 		userMiddlewaresName,
+		roles,
+		version,
 	})
 }
 
@@ -343,15 +479,57 @@ func initTerminalTable() {
 		color.BlueString("路径"),
 		color.BlueString("处理函数"),
 		color.BlueString("中间件"),
+		color.BlueString("角色"),
+		color.BlueString("版本"),
 	})
 	tableRouter.AppendSeparator()
 	tableRouter.SetCaption("Telecommunications Server Routes")
 }
 
-func (s *Server) Start(host string, port string) error {
+// renderValidationRules 输出自定义校验规则及使用该规则的路由
+func (s *Server) renderValidationRules() {
+	rules := validation.Rules()
+	if len(rules) == 0 {
+		return
+	}
+
+	tableRules := table.NewWriter()
+	tableRules.SetOutputMirror(os.Stdout)
+	tableRules.SetStyle(table.StyleDefault)
+	tableRules.Style().Options.SeparateRows = true
+	tableRules.SetTitle(color.CyanString("自定义校验规则"))
+	tableRules.AppendHeader(table.Row{
+		color.BlueString("规则"),
+		color.BlueString("说明"),
+		color.BlueString("路由"),
+	})
+	for _, rule := range rules {
+		routes := []string{}
+		for _, route := range s.routes {
+			if slices.Contains(validationTags(route.RequestType), rule.Tag) {
+				routes = append(routes, route.Method+" "+route.Path)
+			}
+		}
+		tableRules.AppendRow(table.Row{rule.Tag, rule.Description, strings.Join(routes, "\n")})
+	}
+	tableRules.Render()
+	fmt.Println()
+}
+
+// RenderRoutes 输出路由表和自定义校验规则表
+func (s *Server) RenderRoutes(port string) {
 	initTerminalTable()
+	for _, route := range s.routes {
+		addTerminalTable(port, route.Method, route.Path,
+			route.HandlerName, route.Middlewares, route.rolesName(), route.versionName())
+	}
 	tableRouter.Render()
 	fmt.Println()
+	s.renderValidationRules()
+}
+
+func (s *Server) Start(host string, port string) error {
+	s.RenderRoutes(port)
 
 	address := net.JoinHostPort(host, port)
 
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/pkg"
+	"telecommunications_repair_hub/pkg/auth"
+	"telecommunications_repair_hub/pkg/health"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	auth.SetSecret("telecommunications_repair_hub_test_secret")
+	os.Exit(m.Run())
+}
+
+// newTestServer 不连接数据库的 Server，用于测试路由注册和请求处理
+func newTestServer() *Server {
+	e := echo.New()
+	e.Validator = NewValidator()
+	e.Binder = &Binder{}
+	s := &Server{
+		Echo:       e,
+		config:     &config.Config{App: &config.AppConfig{}},
+		health:     health.NewRegistry(),
+		bodyLimits: make(map[string]echo.MiddlewareFunc),
+	}
+	(&HttpServer{}).init(s)
+	return s
+}
+
+func serve(s *Server, method string, path string, body string) (*httptest.ResponseRecorder, map[string]any) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	result := map[string]any{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &result)
+	return rec, result
+}
+
+type echoRequest struct {
+	ID   int    `param:"id" validate:"required,min=1"`
+	Name string `json:"name" validate:"required"`
+}
+
+type echoResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestServer_TypedHandler(t *testing.T) {
+	s := newTestServer()
+	route := s.POST("/echo/:id", func(ctx *TelecommunicationsContext, request *echoRequest) (*echoResponse, error) {
+		if request.Name == "missing" {
+			return nil, pkg.ErrUserNotFound
+		}
+		return &echoResponse{ID: request.ID, Name: request.Name}, nil
+	})
+	assert.Equal(t, "echoResponse", route.ResponseType.Elem().Name())
+
+	rec, body := serve(s, http.MethodPost, "/echo/3", `{"name":"tom"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, float64(0), body["status"])
+	assert.Equal(t, map[string]any{"id": float64(3), "name": "tom"}, body["data"])
+
+	rec, body = serve(s, http.MethodPost, "/echo/3", `{"name":"missing"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, float64(pkg.ErrUserNotFound.Code), body["status"])
+
+	rec, body = serve(s, http.MethodPost, "/echo/0", `{"name":"tom"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, float64(pkg.ErrParamError.Code), body["status"])
+}
+
+func TestServer_ErrorOnlyHandler(t *testing.T) {
+	s := newTestServer()
+	s.GET("/ping", func(ctx *TelecommunicationsContext) error {
+		return pkg.ErrNoPermission
+	})
+
+	rec, body := serve(s, http.MethodGet, "/ping", "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, float64(pkg.ErrNoPermission.Code), body["status"])
+
+	rec, body = serve(s, http.MethodGet, "/not-exists", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, float64(pkg.ErrNotFound.Code), body["status"])
+}
+
+func TestServer_InvalidHandler(t *testing.T) {
+	s := newTestServer()
+	assert.Panics(t, func() {
+		s.GET("/bad", func(ctx *TelecommunicationsContext) (int, string) { return 0, "" })
+	})
+	assert.Panics(t, func() {
+		s.GET("/bad", func(ctx *TelecommunicationsContext) (int, int, error) { return 0, 0, nil })
+	})
+}
+
+func TestServer_RouteBodyLimit(t *testing.T) {
+	s := newTestServer()
+	s.Use(s.bodyLimitMiddleware())
+	handler := func(ctx *TelecommunicationsContext) error { return nil }
+	s.POST("/small", handler)
+	s.POST("/large", handler).BodyLimit("6M")
+	s.POST("/unlimited", handler).BodyLimit("")
+
+	body := strings.Repeat("a", 5<<20+1)
+	rec, _ := serve(s, http.MethodPost, "/small", body)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	rec, _ = serve(s, http.MethodPost, "/large", body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec, _ = serve(s, http.MethodPost, "/large", strings.Repeat("a", 6<<20+1))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	rec, _ = serve(s, http.MethodPost, "/unlimited", strings.Repeat("a", 6<<20+1))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,287 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/models"
+	"telecommunications_repair_hub/models/query"
+	"telecommunications_repair_hub/pkg"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CreateTicketRequest 提交报修工单
+type CreateTicketRequest struct {
+	Address     string                `json:"address" validate:"required,max=255"`
+	RegionID    int                   `json:"region_id" validate:"required,min=1"`
+	Category    models.FaultCategory  `json:"category" validate:"required,oneof=broadband landline mobile iptv other"`
+	Description string                `json:"description" validate:"required,max=2000"`
+	Priority    models.TicketPriority `json:"priority" validate:"omitempty,min=1,max=4"`
+}
+
+// ListTicketRequest 工单列表
+type ListTicketRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=submitted accepted dispatched in_progress resolved closed rejected reopened"`
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Size   int    `query:"size" validate:"omitempty,min=1,max=100"`
+}
+
+// TicketRequest 按工单ID操作
+type TicketRequest struct {
+	ID int `param:"id" validate:"required,min=1"`
+}
+
+// TransitTicketRequest 工单状态流转，派单时必须指定维修人员
+type TransitTicketRequest struct {
+	ID           int                 `param:"id" validate:"required,min=1"`
+	Status       models.TicketStatus `json:"status" validate:"required,oneof=accepted dispatched in_progress resolved closed rejected reopened"`
+	TechnicianID int                 `json:"technician_id" validate:"required_if=Status dispatched,omitempty,min=1"`
+	Remark       string              `json:"remark" validate:"max=500"`
+}
+
+// UploadTicketPhotosRequest 上传工单现场照片，请求体为 multipart/form-data
+type UploadTicketPhotosRequest struct {
+	ID     int                     `param:"id" validate:"required,min=1"`
+	Photos []*multipart.FileHeader `form:"photos" validate:"required,min=1,max=9"`
+}
+
+// TicketPhoto 已保存的工单照片，Path 为相对上传目录的路径
+type TicketPhoto struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	Path string `json:"path"`
+}
+
+// TicketPage 工单分页结果
+type TicketPage struct {
+	Total int64                  `json:"total"`
+	Page  int                    `json:"page"`
+	Size  int                    `json:"size"`
+	Items []*models.RepairTicket `json:"items"`
+}
+
+// 报修人只能自行关闭或重新打开工单，其余流转需要区域管理员及以上角色
+var reporterTicketStatuses = []models.TicketStatus{
+	models.TicketStatusClosed,
+	models.TicketStatusReopened,
+}
+
+func (r *BaseRouter) RegisterTicketRoutes() {
+	allRoles := []models.UserRole{models.UserRoleEndUser}
+
+	// 高频接口使用泛型注册，避免每次请求的反射开销
+	Handle(r, http.MethodPost, "/tickets", createTicket).Roles(allRoles...).Tags("工单").Summary("提交报修工单")
+	Handle(r, http.MethodGet, "/tickets", listTickets).Roles(allRoles...).Tags("工单").Summary("工单列表")
+	Handle(r, http.MethodGet, "/tickets/:id", getTicket).Roles(allRoles...).Tags("工单").Summary("工单详情")
+	r.POST("/tickets/:id/transitions", transitTicket).Roles(allRoles...).Tags("工单").Summary("工单状态流转")
+	r.POST("/tickets/:id/photos", uploadTicketPhotos).Roles(allRoles...).Tags("工单").Summary("上传工单现场照片").
+		BodyLimit(photosBodyLimit(r.config.GetUploadConfig()))
+}
+
+// 单次上传的照片数量上限，与 UploadTicketPhotosRequest.Photos 的 max 校验一致
+const maxTicketPhotos = 9
+
+// photosBodyLimit 上传照片的请求体上限为单张照片上限乘以照片数量，另留 1MB 给 multipart 的分隔符和表单头，
+// 单个文件不限制大小时请求体也不限制
+func photosBodyLimit(uploadConfig *config.UploadConfig) string {
+	if uploadConfig.MaxSize == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dM", uploadConfig.MaxSize*maxTicketPhotos+1)
+}
+
+func createTicket(ctx *TelecommunicationsContext, request *CreateTicketRequest) (*models.RepairTicket, error) {
+	if _, err := findRegion(ctx, request.RegionID); err != nil {
+		return nil, err
+	}
+
+	ticket := &models.RepairTicket{
+		ReporterID:  ctx.User.UserID,
+		Address:     request.Address,
+		RegionID:    request.RegionID,
+		Category:    request.Category,
+		Description: request.Description,
+		Priority:    request.Priority,
+	}
+
+	q := query.Use(ctx.DBInstance.DB).RepairTicket
+	if err := q.WithContext(ctx.Request().Context()).Create(ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+func listTickets(ctx *TelecommunicationsContext, request *ListTicketRequest) (*TicketPage, error) {
+	if request.Page == 0 {
+		request.Page = 1
+	}
+	if request.Size == 0 {
+		request.Size = 20
+	}
+
+	q := query.Use(ctx.DBInstance.DB).RepairTicket
+	do := q.WithContext(ctx.Request().Context()).Order(q.ID.Desc())
+	if ctx.User.Role.Includes(models.UserRoleAreaMgr) {
+		scope, err := ctx.RegionScope(q.RegionID)
+		if err != nil {
+			return nil, err
+		}
+		do = do.Scopes(scope)
+	} else {
+		do = do.Where(q.ReporterID.Eq(ctx.User.UserID))
+	}
+	if request.Status != "" {
+		status := models.TicketStatus(request.Status)
+		do = do.Where(q.Status.Eq(&status))
+	}
+
+	tickets, total, err := do.FindByPage((request.Page-1)*request.Size, request.Size)
+	if err != nil {
+		return nil, err
+	}
+	return &TicketPage{
+		Total: total,
+		Page:  request.Page,
+		Size:  request.Size,
+		Items: tickets,
+	}, nil
+}
+
+func getTicket(ctx *TelecommunicationsContext, request *TicketRequest) (*models.RepairTicket, error) {
+	ticket, err := findTicket(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+func transitTicket(ctx *TelecommunicationsContext, request *TransitTicketRequest) (*models.RepairTicket, error) {
+	ticket, err := findTicket(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ctx.User.Role.Includes(models.UserRoleAreaMgr) && !slices.Contains(reporterTicketStatuses, request.Status) {
+		return nil, pkg.ErrNoPermission
+	}
+
+	from := ticket.Status
+	if request.Status == models.TicketStatusDispatched {
+		err = ticket.Dispatch(request.TechnicianID)
+	} else {
+		err = ticket.TransitTo(request.Status)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if request.Remark != "" {
+		ticket.Remark = request.Remark
+	}
+
+	// 以流转前的状态作为更新条件，并发流转同一工单时只有一个请求能更新成功
+	q := query.Use(ctx.DBInstance.DB).RepairTicket
+	info, err := q.WithContext(ctx.Request().Context()).
+		Where(q.ID.Eq(ticket.ID), q.Status.Eq(&from)).
+		Select(q.ALL).
+		Updates(ticket)
+	if err != nil {
+		return nil, err
+	}
+	if info.RowsAffected == 0 {
+		return nil, pkg.ErrIllegalTicketTransition.Wrapf("工单状态已被修改: %s -> %s", from, request.Status)
+	}
+	return ticket, nil
+}
+
+// 工单照片支持的格式
+var photoExtensions = []string{".jpg", ".jpeg", ".png"}
+
+func uploadTicketPhotos(ctx *TelecommunicationsContext, request *UploadTicketPhotosRequest) ([]*TicketPhoto, error) {
+	ticket, err := findTicket(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadConfig := ctx.Config.GetUploadConfig()
+	maxSize := int64(uploadConfig.MaxSize) << 20
+	for _, photo := range request.Photos {
+		if !slices.Contains(photoExtensions, strings.ToLower(filepath.Ext(photo.Filename))) {
+			return nil, pkg.ErrParamError.Wrapf("照片 %s 格式不支持，仅支持 jpg、png", photo.Filename)
+		}
+		if maxSize > 0 && photo.Size > maxSize {
+			return nil, pkg.ErrRequestTooLarge.Wrapf("照片 %s 超过 %dMB", photo.Filename, uploadConfig.MaxSize)
+		}
+	}
+
+	relativeDir := filepath.Join("tickets", strconv.Itoa(ticket.ID))
+	if err := os.MkdirAll(filepath.Join(uploadConfig.Dir, relativeDir), 0o755); err != nil {
+		return nil, err
+	}
+
+	photos := make([]*TicketPhoto, 0, len(request.Photos))
+	for i, photo := range request.Photos {
+		name := fmt.Sprintf("%d_%d%s", time.Now().UnixNano(), i, strings.ToLower(filepath.Ext(photo.Filename)))
+		path := filepath.Join(relativeDir, name)
+		if err := saveUploadedFile(photo, filepath.Join(uploadConfig.Dir, path)); err != nil {
+			return nil, err
+		}
+		photos = append(photos, &TicketPhoto{
+			Name: photo.Filename,
+			Size: photo.Size,
+			Path: filepath.ToSlash(path),
+		})
+	}
+	return photos, nil
+}
+
+func saveUploadedFile(file *multipart.FileHeader, path string) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+	return err
+}
+
+// findTicket 查询当前用户可见的工单，终端用户只能看到自己提交的工单，
+// 区域管理员只能看到负责区域内的工单
+func findTicket(ctx *TelecommunicationsContext, id int) (*models.RepairTicket, error) {
+	q := query.Use(ctx.DBInstance.DB).RepairTicket
+	do := q.WithContext(ctx.Request().Context()).Where(q.ID.Eq(id))
+	if ctx.User.Role.Includes(models.UserRoleAreaMgr) {
+		scope, err := ctx.RegionScope(q.RegionID)
+		if err != nil {
+			return nil, err
+		}
+		do = do.Scopes(scope)
+	} else {
+		do = do.Where(q.ReporterID.Eq(ctx.User.UserID))
+	}
+
+	ticket, err := do.First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, pkg.ErrTicketNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
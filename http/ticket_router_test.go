@@ -0,0 +1,241 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/consts"
+	"telecommunications_repair_hub/models"
+	"telecommunications_repair_hub/pkg"
+	"telecommunications_repair_hub/pkg/auth"
+	"telecommunications_repair_hub/pkg/db"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ticketTestServer 注册了工单接口的 Server，区域为 省(1) → 市 A(2)、市 B(3)，
+// 区域管理员负责市 A，tokens 按用户名保存各角色用户的访问 token
+type ticketTestServer struct {
+	*Server
+	tokens map[string]string
+}
+
+func newTicketTestServer(t *testing.T) *ticketTestServer {
+	t.Helper()
+	database, err := db.New(&config.Config{Database: &config.DatabaseConfig{
+		Driver: db.DriverSQLite,
+		Path:   filepath.Join(t.TempDir(), "tele.db"),
+	}})
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	_, err = database.MigrateUp(context.Background(), db.MigrateOptions{})
+	require.NoError(t, err)
+
+	province := 1
+	require.NoError(t, database.Create([]*models.Region{
+		{ID: 1, Name: "省", Code: "P1", Level: models.RegionLevelProvince, Path: "/1/"},
+		{ID: 2, ParentID: &province, Name: "市 A", Code: "C2", Level: models.RegionLevelCity, Path: "/1/2/"},
+		{ID: 3, ParentID: &province, Name: "市 B", Code: "C3", Level: models.RegionLevelCity, Path: "/1/3/"},
+	}).Error)
+
+	users := []*models.User{
+		{Username: "reporter", Phone: "13800138001", Role: models.UserRoleEndUser},
+		{Username: "neighbor", Phone: "13800138002", Role: models.UserRoleEndUser},
+		{Username: "manager", Phone: "13800138003", Role: models.UserRoleAreaMgr},
+		{Username: "admin", Phone: "13800138004", Role: models.UserRoleCityAdmin},
+	}
+	require.NoError(t, database.Create(users).Error)
+	require.NoError(t, database.Create(&models.UserRegion{UserID: users[2].ID, RegionID: 2}).Error)
+
+	tokens := map[string]string{}
+	for _, user := range users {
+		token, err := auth.IssueToken(user)
+		require.NoError(t, err)
+		tokens[user.Username] = token
+	}
+
+	s := newTestServer()
+	s.db = database
+	s.config.App.Upload = &config.UploadConfig{Dir: t.TempDir(), MaxSize: 10}
+	NewBaseRouter(s).RegisterTicketRoutes()
+	return &ticketTestServer{Server: s, tokens: tokens}
+}
+
+// serve 以 user 的身份发送请求，user 为空时不携带访问 token
+func (s *ticketTestServer) serve(user string, method string, path string, body string) (*httptest.ResponseRecorder, map[string]any) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	if user != "" {
+		req.Header.Set(echo.HeaderAuthorization, consts.JWT_HEADER_PREFIX+s.tokens[user])
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	result := map[string]any{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &result)
+	return rec, result
+}
+
+// createTicket 以 user 的身份在 regionID 提交工单，返回工单ID
+func (s *ticketTestServer) createTicket(t *testing.T, user string, regionID int) int {
+	t.Helper()
+	rec, body := s.serve(user, http.MethodPost, "/tickets",
+		fmt.Sprintf(`{"address":"幸福路 1 号","region_id":%d,"category":"broadband","description":"无法上网"}`, regionID))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	return int(body["data"].(map[string]any)["ID"].(float64))
+}
+
+func ticketIDs(body map[string]any) []int {
+	ids := []int{}
+	for _, item := range body["data"].(map[string]any)["items"].([]any) {
+		ids = append(ids, int(item.(map[string]any)["ID"].(float64)))
+	}
+	return ids
+}
+
+func TestTicketRoutes_Create(t *testing.T) {
+	s := newTicketTestServer(t)
+
+	rec, body := s.serve("reporter", http.MethodPost, "/tickets",
+		`{"address":"幸福路 1 号","region_id":2,"category":"broadband","description":"无法上网"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	ticket := body["data"].(map[string]any)
+	assert.Equal(t, string(models.TicketStatusSubmitted), ticket["Status"])
+	assert.Equal(t, float64(models.TicketPriorityNormal), ticket["Priority"])
+
+	rec, body = s.serve("reporter", http.MethodPost, "/tickets",
+		`{"address":"幸福路 1 号","region_id":99,"category":"broadband","description":"无法上网"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, float64(pkg.ErrRegionNotFound.Code), body["status"])
+
+	rec, body = s.serve("reporter", http.MethodPost, "/tickets",
+		`{"address":"幸福路 1 号","region_id":2,"category":"gas","description":"无法上网"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, float64(pkg.ErrParamError.Code), body["status"])
+
+	rec, body = s.serve("", http.MethodPost, "/tickets",
+		`{"address":"幸福路 1 号","region_id":2,"category":"broadband","description":"无法上网"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, float64(pkg.ErrInvalidToken.Code), body["status"])
+}
+
+func TestTicketRoutes_ListAndFind(t *testing.T) {
+	s := newTicketTestServer(t)
+	inCityA := s.createTicket(t, "reporter", 2)
+	inCityB := s.createTicket(t, "neighbor", 3)
+
+	// 终端用户只能看到自己提交的工单，区域管理员只能看到负责区域内的工单，总管理员不限制
+	for user, visible := range map[string][]int{
+		"reporter": {inCityA},
+		"neighbor": {inCityB},
+		"manager":  {inCityA},
+		"admin":    {inCityB, inCityA},
+	} {
+		rec, body := s.serve(user, http.MethodGet, "/tickets", "")
+		assert.Equal(t, http.StatusOK, rec.Code, user)
+		assert.Equal(t, visible, ticketIDs(body), user)
+		assert.Equal(t, float64(len(visible)), body["data"].(map[string]any)["total"], user)
+
+		for _, id := range []int{inCityA, inCityB} {
+			rec, body := s.serve(user, http.MethodGet, fmt.Sprintf("/tickets/%d", id), "")
+			if slices.Contains(visible, id) {
+				assert.Equal(t, http.StatusOK, rec.Code, user)
+				assert.Equal(t, float64(id), body["data"].(map[string]any)["ID"], user)
+			} else {
+				assert.Equal(t, http.StatusNotFound, rec.Code, user)
+				assert.Equal(t, float64(pkg.ErrTicketNotFound.Code), body["status"], user)
+			}
+		}
+	}
+
+	rec, body := s.serve("admin", http.MethodGet, "/tickets?status=accepted", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, ticketIDs(body))
+
+	rec, _ = s.serve("", http.MethodGet, "/tickets", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestTicketRoutes_Transition(t *testing.T) {
+	s := newTicketTestServer(t)
+	inCityA := s.createTicket(t, "reporter", 2)
+	inCityB := s.createTicket(t, "neighbor", 3)
+	transitions := func(id int) string { return fmt.Sprintf("/tickets/%d/transitions", id) }
+
+	// 报修人只能关闭或重新打开工单
+	rec, body := s.serve("reporter", http.MethodPost, transitions(inCityA), `{"status":"accepted"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, float64(pkg.ErrNoPermission.Code), body["status"])
+
+	// 区域管理员不能处理负责区域外的工单
+	rec, body = s.serve("manager", http.MethodPost, transitions(inCityB), `{"status":"accepted"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, float64(pkg.ErrTicketNotFound.Code), body["status"])
+
+	rec, body = s.serve("manager", http.MethodPost, transitions(inCityA), `{"status":"accepted","remark":"已受理"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, string(models.TicketStatusAccepted), body["data"].(map[string]any)["Status"])
+	assert.Equal(t, "已受理", body["data"].(map[string]any)["Remark"])
+
+	// 派单必须指定维修人员
+	rec, body = s.serve("manager", http.MethodPost, transitions(inCityA), `{"status":"dispatched"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, float64(pkg.ErrParamError.Code), body["status"])
+
+	rec, body = s.serve("manager", http.MethodPost, transitions(inCityA), `{"status":"dispatched","technician_id":7}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, float64(7), body["data"].(map[string]any)["TechnicianID"])
+
+	// 非法流转返回 409，工单状态不变
+	rec, body = s.serve("admin", http.MethodPost, transitions(inCityB), `{"status":"resolved"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, float64(pkg.ErrIllegalTicketTransition.Code), body["status"])
+	_, body = s.serve("admin", http.MethodGet, fmt.Sprintf("/tickets/%d", inCityB), "")
+	assert.Equal(t, string(models.TicketStatusSubmitted), body["data"].(map[string]any)["Status"])
+
+	rec, body = s.serve("neighbor", http.MethodPost, transitions(inCityB), `{"status":"closed"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, float64(pkg.ErrIllegalTicketTransition.Code), body["status"])
+
+	// 未知状态和初始状态属于参数错误
+	for _, status := range []string{"foo", "submitted"} {
+		rec, body = s.serve("admin", http.MethodPost, transitions(inCityB), fmt.Sprintf(`{"status":%q}`, status))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, status)
+		assert.Equal(t, float64(pkg.ErrParamError.Code), body["status"], status)
+	}
+}
+
+func TestTicketRoutes_TransitionStaleStatus(t *testing.T) {
+	s := newTicketTestServer(t)
+	id := s.createTicket(t, "reporter", 2)
+
+	// 读取工单后、更新前工单已被其他请求驳回，本次流转不能覆盖驳回结果
+	var once sync.Once
+	require.NoError(t, s.db.Callback().Update().Before("gorm:update").Register("test:concurrent_transition", func(tx *gorm.DB) {
+		once.Do(func() {
+			require.NoError(t, tx.Session(&gorm.Session{NewDB: true}).
+				Exec("UPDATE "+models.RepairTicket{}.TableName()+" SET status = ? WHERE id = ?", models.TicketStatusRejected, id).Error)
+		})
+	}))
+
+	rec, body := s.serve("manager", http.MethodPost, fmt.Sprintf("/tickets/%d/transitions", id), `{"status":"accepted"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, float64(pkg.ErrIllegalTicketTransition.Code), body["status"])
+
+	_, body = s.serve("admin", http.MethodGet, fmt.Sprintf("/tickets/%d", id), "")
+	assert.Equal(t, string(models.TicketStatusRejected), body["data"].(map[string]any)["Status"])
+}
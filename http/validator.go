@@ -0,0 +1,228 @@
+package http
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"telecommunications_repair_hub/pkg"
+	"telecommunications_repair_hub/pkg/validation"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	zh_translations "github.com/go-playground/validator/v10/translations/zh"
+)
+
+type Validator struct {
+	validator  *validator.Validate
+	translator *ut.UniversalTranslator
+}
+
+type ValidationErrors struct {
+	Errors []validator.FieldError `json:"errors"`
+
+	translator *ut.UniversalTranslator
+}
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	// 字段名，取自 query、json、path、form、header、cookie、param 标签，嵌套字段以 . 分隔
+	Field string `json:"field"`
+	// 未通过的校验规则
+	Rule string `json:"rule"`
+	// 校验规则的参数，如 max=10 中的 10
+	Param string `json:"param,omitempty"`
+	// 按请求语言翻译的提示
+	Message string `json:"message"`
+}
+
+// FieldErrors 全部未通过校验的字段，作为参数错误响应的 data 返回
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldError := range e {
+		messages = append(messages, fieldError.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// 没有翻译的校验规则使用的提示
+var fallbackMessages = map[string]string{
+	"zh": "参数 %s 无法通过 %s 规则的验证",
+	"en": "parameter %s failed on the %s rule",
+}
+
+// NewValidator 创建校验器，注册 zh、en 两种语言的提示，字段名使用请求参数标签
+func NewValidator() *Validator {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(fieldTagName)
+
+	zhLocale := zh.New()
+	translator := ut.New(zhLocale, zhLocale, en.New())
+
+	zhTranslator, _ := translator.GetTranslator("zh")
+	if err := zh_translations.RegisterDefaultTranslations(validate, zhTranslator); err != nil {
+		panic(err)
+	}
+	enTranslator, _ := translator.GetTranslator("en")
+	if err := en_translations.RegisterDefaultTranslations(validate, enTranslator); err != nil {
+		panic(err)
+	}
+
+	for _, rule := range validation.Rules() {
+		if err := registerRule(validate, translator, rule); err != nil {
+			panic(err)
+		}
+	}
+
+	return &Validator{
+		validator:  validate,
+		translator: translator,
+	}
+}
+
+// registerRule 注册自定义校验规则及其各语言的提示
+func registerRule(validate *validator.Validate, translator *ut.UniversalTranslator, rule *validation.Rule) error {
+	if err := validate.RegisterValidation(rule.Tag, rule.Func); err != nil {
+		return err
+	}
+	for language, message := range rule.Messages {
+		trans, found := translator.GetTranslator(language)
+		if !found {
+			continue
+		}
+		err := validate.RegisterTranslation(rule.Tag, trans, func(trans ut.Translator) error {
+			return trans.Add(rule.Tag, message, true)
+		}, func(trans ut.Translator, fe validator.FieldError) string {
+			message, _ := trans.T(fe.Tag(), fe.Field(), fe.Param())
+			return message
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *ValidationErrors) Error() string {
+	errors := []string{}
+	for _, error := range v.Errors {
+		errors = append(errors, error.Error())
+	}
+	return strings.Join(errors, ", ")
+}
+
+// Translate 按语言翻译全部字段的校验错误
+func (v *ValidationErrors) Translate(language string) FieldErrors {
+	var translator ut.Translator
+	if v.translator != nil {
+		translator, _ = v.translator.GetTranslator(language)
+	}
+	fallback, ok := fallbackMessages[language]
+	if !ok {
+		fallback = fallbackMessages[pkg.DefaultLanguage]
+	}
+
+	fieldErrors := make(FieldErrors, 0, len(v.Errors))
+	for _, fe := range v.Errors {
+		field := fieldPath(fe)
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+
+		message := ""
+		if translator != nil {
+			message = fe.Translate(translator)
+		}
+		// 没有注册翻译的规则，Translate 返回的是英文的原始错误
+		if message == "" || message == fe.Error() {
+			message = fmt.Sprintf(fallback, field, rule)
+		}
+
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: message,
+		})
+	}
+	return fieldErrors
+}
+
+func (v *Validator) Validate(i interface{}) error {
+	err := v.validator.Struct(i)
+	if err == nil {
+		return nil
+	}
+	// 处理验证错误
+	if validatorErrors, ok := err.(validator.ValidationErrors); ok {
+		return &ValidationErrors{
+			Errors:     validatorErrors,
+			translator: v.translator,
+		}
+	}
+
+	return err
+}
+
+// fieldTagName 校验错误中的字段名，按 query、json、path、form、header、cookie、param 的顺序取第一个标签
+func fieldTagName(field reflect.StructField) string {
+	for _, key := range []string{"query", "json", "path", "form", "header", "cookie", "param"} {
+		tag, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// fieldPath 去掉命名空间中最外层的结构体名：CreateRegionRequest.region_ids[0] -> region_ids[0]
+func fieldPath(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}
+
+// validationTags 请求参数类型中使用的全部校验规则名，包括嵌套结构体字段
+func validationTags(t reflect.Type) []string {
+	tags := []string{}
+	visited := map[reflect.Type]bool{}
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct || visited[t] {
+			return
+		}
+		visited[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+				tag, _, _ := strings.Cut(rule, "=")
+				for _, tag := range strings.Split(tag, "|") {
+					if tag != "" && !slices.Contains(tags, tag) {
+						tags = append(tags, tag)
+					}
+				}
+			}
+			walk(field.Type)
+		}
+	}
+	walk(t)
+	return tags
+}
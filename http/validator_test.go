@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"telecommunications_repair_hub/pkg"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type validateRequest struct {
+	Page      int    `query:"page" validate:"min=1"`
+	Name      string `json:"name" validate:"required"`
+	RegionIDs []int  `json:"region_ids" validate:"dive,min=1"`
+}
+
+func TestRequestValidator_AllFieldErrors(t *testing.T) {
+	s := newTestServer()
+	Handle(s, http.MethodPost, "/validate", func(ctx *TelecommunicationsContext, request *validateRequest) (any, error) {
+		return nil, nil
+	})
+
+	validate := func(language string) (*httptest.ResponseRecorder, []FieldError) {
+		req := httptest.NewRequest(http.MethodPost, "/validate?page=0", strings.NewReader(`{"region_ids":[1,0]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("Accept-Language", language)
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+
+		body := struct {
+			Status int          `json:"status"`
+			Data   []FieldError `json:"data"`
+		}{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, pkg.ErrParamError.Code, body.Status)
+		return rec, body.Data
+	}
+
+	rec, fieldErrors := validate("zh-CN")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "page", Rule: "min", Param: "1", Message: "page最小只能为1"},
+		{Field: "name", Rule: "required", Message: "name为必填字段"},
+		{Field: "region_ids[1]", Rule: "min", Param: "1", Message: "region_ids[1]最小只能为1"},
+	}, fieldErrors)
+
+	_, fieldErrors = validate("en-US,en;q=0.9")
+	assert.Len(t, fieldErrors, 3)
+	assert.Equal(t, "name is a required field", fieldErrors[1].Message)
+}
+
+type customRuleRequest struct {
+	Phone     string `json:"phone" validate:"required,mobile=china_telecom"`
+	FaultCode string `json:"fault_code" validate:"omitempty,fault_code"`
+}
+
+func TestRequestValidator_CustomRules(t *testing.T) {
+	s := newTestServer()
+	route := Handle(s, http.MethodPost, "/custom", func(ctx *TelecommunicationsContext, request *customRuleRequest) (any, error) {
+		return nil, nil
+	})
+	assert.Equal(t, []string{"required", "mobile", "omitempty", "fault_code"}, validationTags(route.RequestType))
+
+	rec, body := serve(s, http.MethodPost, "/custom", `{"phone":"13800138000","fault_code":"XX-1"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, []any{
+		map[string]any{"field": "phone", "rule": "mobile", "param": "china_telecom", "message": "phone必须是有效的手机号码"},
+		map[string]any{"field": "fault_code", "rule": "fault_code", "message": "fault_code必须是有效的故障码"},
+	}, body["data"])
+
+	rec, _ = serve(s, http.MethodPost, "/custom", `{"phone":"18912345678","fault_code":"BB-0012"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	doc := NewOpenAPI(OpenAPIInfo{}, s.Routes())
+	schema := doc.Paths["/custom"]["post"].RequestBody.Content[echo.MIMEApplicationJSON].Schema
+	assert.Equal(t, `^1[3-9]\d{9}$`, schema.Properties["phone"].Pattern)
+	assert.Contains(t, schema.Properties["fault_code"].Description, "故障码")
+}
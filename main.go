@@ -1,7 +1,11 @@
 package main
 
-import "telecommunications_repair_hub/server"
+import (
+	"os"
+
+	"telecommunications_repair_hub/command"
+)
 
 func main() {
-	server.NewTelecommunicationsServer()
+	os.Exit(command.Execute())
 }
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"regexp"
+
+	"telecommunications_repair_hub/pkg/validation"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// 故障码格式：故障类别前缀-4 位编号，如宽带故障 BB-0012
+var faultCodePattern = regexp.MustCompile(`^(BB|LL|MB|TV|OT)-\d{4}$`)
+
+// 故障码前缀对应的故障类别
+var faultCodePrefixes = map[string]FaultCategory{
+	"BB": FaultCategoryBroadband,
+	"LL": FaultCategoryLandline,
+	"MB": FaultCategoryMobile,
+	"TV": FaultCategoryIPTV,
+	"OT": FaultCategoryOther,
+}
+
+// FaultCodeCategory 故障码对应的故障类别，格式错误时返回 false
+func FaultCodeCategory(code string) (FaultCategory, bool) {
+	if !faultCodePattern.MatchString(code) {
+		return "", false
+	}
+	category, ok := faultCodePrefixes[code[:2]]
+	return category, ok
+}
+
+func init() {
+	validation.Register(validation.Rule{
+		Tag: "fault_code",
+		Func: func(fl validator.FieldLevel) bool {
+			_, ok := FaultCodeCategory(fl.Field().String())
+			return ok
+		},
+		Description: "故障码，故障类别前缀（BB 宽带、LL 固话、MB 移动网络、TV 电视、OT 其他）-4 位编号",
+		Pattern:     faultCodePattern.String(),
+		Messages: map[string]string{
+			"zh": "{0}必须是有效的故障码",
+			"en": "{0} must be a valid fault code",
+		},
+	})
+}
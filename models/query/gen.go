@@ -16,34 +16,49 @@ import (
 )
 
 var (
-	Q    = new(Query)
-	User *user
+	Q            = new(Query)
+	Region       *region
+	RepairTicket *repairTicket
+	User         *user
+	UserRegion   *userRegion
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	Region = &Q.Region
+	RepairTicket = &Q.RepairTicket
 	User = &Q.User
+	UserRegion = &Q.UserRegion
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:   db,
-		User: newUser(db, opts...),
+		db:           db,
+		Region:       newRegion(db, opts...),
+		RepairTicket: newRepairTicket(db, opts...),
+		User:         newUser(db, opts...),
+		UserRegion:   newUserRegion(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	User user
+	Region       region
+	RepairTicket repairTicket
+	User         user
+	UserRegion   userRegion
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:   db,
-		User: q.User.clone(db),
+		db:           db,
+		Region:       q.Region.clone(db),
+		RepairTicket: q.RepairTicket.clone(db),
+		User:         q.User.clone(db),
+		UserRegion:   q.UserRegion.clone(db),
 	}
 }
 
@@ -57,18 +72,27 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:   db,
-		User: q.User.replaceDB(db),
+		db:           db,
+		Region:       q.Region.replaceDB(db),
+		RepairTicket: q.RepairTicket.replaceDB(db),
+		User:         q.User.replaceDB(db),
+		UserRegion:   q.UserRegion.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	User IUserDo
+	Region       IRegionDo
+	RepairTicket IRepairTicketDo
+	User         IUserDo
+	UserRegion   IUserRegionDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		User: q.User.WithContext(ctx),
+		Region:       q.Region.WithContext(ctx),
+		RepairTicket: q.RepairTicket.WithContext(ctx),
+		User:         q.User.WithContext(ctx),
+		UserRegion:   q.UserRegion.WithContext(ctx),
 	}
 }
 
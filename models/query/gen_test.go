@@ -77,7 +77,10 @@ func Test_WithContext(t *testing.T) {
 	qCtx := query.WithContext(context.WithValue(context.Background(), key, value))
 
 	for _, ctx := range []context.Context{
+		qCtx.Region.UnderlyingDB().Statement.Context,
+		qCtx.RepairTicket.UnderlyingDB().Statement.Context,
 		qCtx.User.UnderlyingDB().Statement.Context,
+		qCtx.UserRegion.UnderlyingDB().Statement.Context,
 	} {
 		if v := ctx.Value(key); v != value {
 			t.Errorf("get value from context fail, expect %q, got %q", value, v)
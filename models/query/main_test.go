@@ -0,0 +1,20 @@
+package query
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"telecommunications_repair_hub/models"
+)
+
+// 生成的单元测试会插入多条空区域，测试库中的区域编码改为普通索引，唯一约束由 pkg/db 的迁移测试覆盖
+func TestMain(m *testing.M) {
+	if err := _gen_test_db.Migrator().DropIndex(&models.Region{}, "idx_tele_region_code"); err != nil {
+		fmt.Printf("Error: drop index idx_tele_region_code fail: %s", err)
+	}
+	if err := _gen_test_db.Exec("CREATE INDEX idx_tele_region_code ON tele_region (code)").Error; err != nil {
+		fmt.Printf("Error: create index idx_tele_region_code fail: %s", err)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,40 @@
+package query
+
+import (
+	"context"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+// ManagedRegionIDs 用户负责的区域及其全部下级区域ID
+func (q *Query) ManagedRegionIDs(ctx context.Context, userID int) ([]int, error) {
+	r, ur := q.Region, q.UserRegion
+
+	assigned, err := r.WithContext(ctx).
+		Join(ur, ur.RegionID.EqCol(r.ID)).
+		Where(ur.UserID.Eq(userID)).
+		Find()
+	if err != nil || len(assigned) == 0 {
+		return nil, err
+	}
+
+	do := r.WithContext(ctx).Where(r.Path.Like(assigned[0].Path + "%"))
+	for _, region := range assigned[1:] {
+		do = do.Or(r.Path.Like(region.Path + "%"))
+	}
+
+	regionIDs := []int{}
+	if err := do.Pluck(r.ID, &regionIDs); err != nil {
+		return nil, err
+	}
+	return regionIDs, nil
+}
+
+// RegionScope 将查询限制在 regionIDs 指定的区域内，regionIDs 为空时不返回任何数据，
+// 配合 Scopes 使用：q.RepairTicket.WithContext(ctx).Scopes(RegionScope(q.RepairTicket.RegionID, ids))
+func RegionScope(column field.Int, regionIDs []int) func(gen.Dao) gen.Dao {
+	return func(dao gen.Dao) gen.Dao {
+		return dao.Where(column.In(regionIDs...))
+	}
+}
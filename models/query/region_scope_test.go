@@ -0,0 +1,56 @@
+package query
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"telecommunications_repair_hub/models"
+)
+
+func Test_ManagedRegionIDs(t *testing.T) {
+	q := Use(_gen_test_db)
+	ctx := context.Background()
+
+	// 省 → 市 → 两个区县，另一个省不应被包含
+	tree := []*models.Region{
+		{ID: 9001, Name: "省", Code: "T9001", Level: models.RegionLevelProvince, Path: "/9001/"},
+		{ID: 9002, Name: "市", Code: "T9002", Level: models.RegionLevelCity, Path: "/9001/9002/"},
+		{ID: 9003, Name: "区县A", Code: "T9003", Level: models.RegionLevelDistrict, Path: "/9001/9002/9003/"},
+		{ID: 9004, Name: "区县B", Code: "T9004", Level: models.RegionLevelDistrict, Path: "/9001/9002/9004/"},
+		{ID: 9005, Name: "其他省", Code: "T9005", Level: models.RegionLevelProvince, Path: "/9005/"},
+	}
+	if err := q.Region.WithContext(ctx).Save(tree...); err != nil {
+		t.Fatal("create regions fail:", err)
+	}
+	if err := q.UserRegion.WithContext(ctx).Save(&models.UserRegion{UserID: 9100, RegionID: 9002}); err != nil {
+		t.Fatal("create user region fail:", err)
+	}
+
+	ids, err := q.ManagedRegionIDs(ctx, 9100)
+	if err != nil {
+		t.Fatal("ManagedRegionIDs fail:", err)
+	}
+	sort.Ints(ids)
+	assert(t, "ManagedRegionIDs", ids, []int{9002, 9003, 9004})
+
+	ids, err = q.ManagedRegionIDs(ctx, 9101)
+	if err != nil {
+		t.Fatal("ManagedRegionIDs fail:", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("ManagedRegionIDs() for user without regions = %v, want empty", ids)
+	}
+
+	count, err := q.Region.WithContext(ctx).Scopes(RegionScope(q.Region.ID, []int{9003, 9004})).Count()
+	if err != nil {
+		t.Fatal("RegionScope fail:", err)
+	}
+	assert(t, "RegionScope", count, int64(2))
+
+	count, err = q.Region.WithContext(ctx).Scopes(RegionScope(q.Region.ID, nil)).Count()
+	if err != nil {
+		t.Fatal("RegionScope fail:", err)
+	}
+	assert(t, "RegionScope", count, int64(0))
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RegionLevel 区域层级
+type RegionLevel int
+
+const (
+	// 省
+	RegionLevelProvince RegionLevel = 1
+	// 市
+	RegionLevelCity RegionLevel = 2
+	// 区县
+	RegionLevelDistrict RegionLevel = 3
+	// 网格
+	RegionLevelGrid RegionLevel = 4
+)
+
+// Region 服务区域，按 省 → 市 → 区县 → 网格 组成树
+type Region struct {
+	ID       int         `gorm:"column:id;primaryKey;autoIncrement;comment:区域ID"`
+	ParentID *int        `gorm:"column:parent_id;index;comment:上级区域ID"`
+	Name     string      `gorm:"column:name;not null;comment:区域名称"`
+	Code     string      `gorm:"column:code;not null;uniqueIndex:idx_tele_region_code;comment:区域编码"`
+	Level    RegionLevel `gorm:"column:level;not null;comment:区域层级"`
+	// 从根区域到当前区域的ID路径，如 /1/5/12/，用于查询全部下级区域
+	Path string `gorm:"column:path;not null;index;comment:区域路径"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;comment:创建时间"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;comment:更新时间"`
+
+	Children []*Region `gorm:"-"`
+}
+
+func (Region) TableName() string {
+	return GetTableNames("region")
+}
+
+// BuildPath 根据上级区域生成区域路径，需在区域ID生成后调用
+func (r *Region) BuildPath(parent *Region) {
+	path := "/"
+	if parent != nil {
+		path = parent.Path
+	}
+	r.Path = path + strconv.Itoa(r.ID) + "/"
+}
+
+func (r *Region) BeforeCreate(tx *gorm.DB) (err error) {
+	r.CreatedAt = time.Now()
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *Region) BeforeUpdate(tx *gorm.DB) (err error) {
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// BuildRegionTree 将扁平的区域列表组装成树，上级区域不在列表中的区域作为根节点
+func BuildRegionTree(regions []*Region) []*Region {
+	nodes := make(map[int]*Region, len(regions))
+	for _, region := range regions {
+		region.Children = nil
+		nodes[region.ID] = region
+	}
+
+	roots := []*Region{}
+	for _, region := range regions {
+		if region.ParentID != nil {
+			if parent, ok := nodes[*region.ParentID]; ok {
+				parent.Children = append(parent.Children, region)
+				continue
+			}
+		}
+		roots = append(roots, region)
+	}
+	return roots
+}
+
+// UserRegion 区域管理员负责的区域，一个管理员可以负责多个区域
+type UserRegion struct {
+	ID       int `gorm:"column:id;primaryKey;autoIncrement;comment:ID"`
+	UserID   int `gorm:"column:user_id;not null;index;comment:用户ID"`
+	RegionID int `gorm:"column:region_id;not null;index;comment:区域ID"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;comment:创建时间"`
+}
+
+func (UserRegion) TableName() string {
+	return GetTableNames("user_region")
+}
+
+func (u *UserRegion) BeforeCreate(tx *gorm.DB) (err error) {
+	u.CreatedAt = time.Now()
+	return nil
+}
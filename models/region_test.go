@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRegionTree(t *testing.T) {
+	province, city := 1, 2
+	regions := []*Region{
+		{ID: 1, Level: RegionLevelProvince},
+		{ID: 2, ParentID: &province, Level: RegionLevelCity},
+		{ID: 3, ParentID: &city, Level: RegionLevelDistrict},
+		{ID: 4, ParentID: &city, Level: RegionLevelDistrict},
+	}
+
+	roots := BuildRegionTree(regions)
+	assert.Len(t, roots, 1)
+	assert.Equal(t, 1, roots[0].ID)
+	assert.Len(t, roots[0].Children, 1)
+	assert.Len(t, roots[0].Children[0].Children, 2)
+}
+
+func TestRegion_BuildPath(t *testing.T) {
+	province := &Region{ID: 1}
+	province.BuildPath(nil)
+	assert.Equal(t, "/1/", province.Path)
+
+	city := &Region{ID: 5}
+	city.BuildPath(province)
+	assert.Equal(t, "/1/5/", city.Path)
+}
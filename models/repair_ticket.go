@@ -0,0 +1,197 @@
+package models
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"telecommunications_repair_hub/pkg"
+
+	"gorm.io/gorm"
+)
+
+// TicketStatus 工单状态
+type TicketStatus string
+
+const (
+	// 已提交
+	TicketStatusSubmitted TicketStatus = "submitted"
+	// 已受理
+	TicketStatusAccepted TicketStatus = "accepted"
+	// 已派单
+	TicketStatusDispatched TicketStatus = "dispatched"
+	// 维修中
+	TicketStatusInProgress TicketStatus = "in_progress"
+	// 已解决
+	TicketStatusResolved TicketStatus = "resolved"
+	// 已关闭
+	TicketStatusClosed TicketStatus = "closed"
+	// 已驳回
+	TicketStatusRejected TicketStatus = "rejected"
+	// 重新打开
+	TicketStatusReopened TicketStatus = "reopened"
+)
+
+// 工单状态机：key 为当前状态，value 为允许流转到的状态
+var ticketTransitions = map[TicketStatus][]TicketStatus{
+	TicketStatusSubmitted:  {TicketStatusAccepted, TicketStatusRejected},
+	TicketStatusAccepted:   {TicketStatusDispatched, TicketStatusRejected},
+	TicketStatusDispatched: {TicketStatusInProgress},
+	TicketStatusInProgress: {TicketStatusResolved},
+	TicketStatusResolved:   {TicketStatusClosed, TicketStatusReopened},
+	TicketStatusClosed:     {TicketStatusReopened},
+	TicketStatusRejected:   {TicketStatusClosed, TicketStatusReopened},
+	TicketStatusReopened:   {TicketStatusAccepted, TicketStatusRejected},
+}
+
+func (s TicketStatus) String() string {
+	return string(s)
+}
+
+// Valid 是否为已定义的工单状态
+func (s TicketStatus) Valid() bool {
+	_, ok := ticketTransitions[s]
+	return ok
+}
+
+// CanTransitTo 是否允许从状态 s 流转到 to
+func (s TicketStatus) CanTransitTo(to TicketStatus) bool {
+	for _, next := range ticketTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStatuses 状态 s 允许流转到的状态
+func (s TicketStatus) NextStatuses() []TicketStatus {
+	return ticketTransitions[s]
+}
+
+func (s *TicketStatus) Value() (driver.Value, error) {
+	return string(*s), nil
+}
+
+func (s *TicketStatus) Scan(value interface{}) error {
+	*s = TicketStatus(value.(string))
+	return nil
+}
+
+// FaultCategory 故障类别
+type FaultCategory string
+
+const (
+	// 宽带
+	FaultCategoryBroadband FaultCategory = "broadband"
+	// 固话
+	FaultCategoryLandline FaultCategory = "landline"
+	// 移动网络
+	FaultCategoryMobile FaultCategory = "mobile"
+	// 电视
+	FaultCategoryIPTV FaultCategory = "iptv"
+	// 其他
+	FaultCategoryOther FaultCategory = "other"
+)
+
+func (c FaultCategory) String() string {
+	return string(c)
+}
+
+func (c *FaultCategory) Value() (driver.Value, error) {
+	return string(*c), nil
+}
+
+func (c *FaultCategory) Scan(value interface{}) error {
+	*c = FaultCategory(value.(string))
+	return nil
+}
+
+// TicketPriority 工单优先级，数值越大越紧急
+type TicketPriority int
+
+const (
+	TicketPriorityLow    TicketPriority = 1
+	TicketPriorityNormal TicketPriority = 2
+	TicketPriorityHigh   TicketPriority = 3
+	TicketPriorityUrgent TicketPriority = 4
+)
+
+type RepairTicket struct {
+	ID         int    `gorm:"column:id;primaryKey;autoIncrement;comment:工单ID"`
+	ReporterID int    `gorm:"column:reporter_id;not null;index;comment:报修人ID"`
+	Address    string `gorm:"column:address;not null;comment:报修地址"`
+	RegionID   int    `gorm:"column:region_id;not null;index;comment:所属区域ID"`
+
+	Category    FaultCategory  `gorm:"column:category;not null;comment:故障类别"`
+	Description string         `gorm:"column:description;not null;comment:故障描述"`
+	Priority    TicketPriority `gorm:"column:priority;not null;default:2;comment:优先级"`
+
+	Status       TicketStatus `gorm:"column:status;not null;index;comment:工单状态"`
+	TechnicianID *int         `gorm:"column:technician_id;index;comment:维修人员ID"`
+	Remark       string       `gorm:"column:remark;comment:处理备注"`
+
+	AcceptedAt   *time.Time `gorm:"column:accepted_at;comment:受理时间"`
+	DispatchedAt *time.Time `gorm:"column:dispatched_at;comment:派单时间"`
+	StartedAt    *time.Time `gorm:"column:started_at;comment:开始维修时间"`
+	ResolvedAt   *time.Time `gorm:"column:resolved_at;comment:解决时间"`
+	ClosedAt     *time.Time `gorm:"column:closed_at;comment:关闭时间"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;comment:创建时间"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;comment:更新时间"`
+}
+
+func (RepairTicket) TableName() string {
+	return GetTableNames("repair_ticket")
+}
+
+// TransitTo 按状态机流转工单状态，并记录对应节点的时间，非法流转返回 *pkg.TicketTransitionError
+func (t *RepairTicket) TransitTo(to TicketStatus) error {
+	if !t.Status.CanTransitTo(to) {
+		return &pkg.TicketTransitionError{From: t.Status.String(), To: to.String()}
+	}
+
+	now := time.Now()
+	switch to {
+	case TicketStatusAccepted:
+		t.AcceptedAt = &now
+	case TicketStatusDispatched:
+		t.DispatchedAt = &now
+	case TicketStatusInProgress:
+		t.StartedAt = &now
+	case TicketStatusResolved:
+		t.ResolvedAt = &now
+	case TicketStatusClosed, TicketStatusRejected:
+		t.ClosedAt = &now
+	case TicketStatusReopened:
+		t.ResolvedAt = nil
+		t.ClosedAt = nil
+	}
+	t.Status = to
+	return nil
+}
+
+// Dispatch 派单给维修人员
+func (t *RepairTicket) Dispatch(technicianID int) error {
+	if err := t.TransitTo(TicketStatusDispatched); err != nil {
+		return err
+	}
+	t.TechnicianID = &technicianID
+	return nil
+}
+
+func (t *RepairTicket) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.Status == "" {
+		t.Status = TicketStatusSubmitted
+	}
+	if t.Priority == 0 {
+		t.Priority = TicketPriorityNormal
+	}
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *RepairTicket) BeforeUpdate(tx *gorm.DB) (err error) {
+	t.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+
+	"telecommunications_repair_hub/pkg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepairTicket_Lifecycle(t *testing.T) {
+	ticket := &RepairTicket{Status: TicketStatusSubmitted}
+
+	assert.NoError(t, ticket.TransitTo(TicketStatusAccepted))
+	assert.NotNil(t, ticket.AcceptedAt)
+
+	assert.NoError(t, ticket.Dispatch(42))
+	assert.Equal(t, 42, *ticket.TechnicianID)
+	assert.NotNil(t, ticket.DispatchedAt)
+
+	assert.NoError(t, ticket.TransitTo(TicketStatusInProgress))
+	assert.NoError(t, ticket.TransitTo(TicketStatusResolved))
+	assert.NotNil(t, ticket.ResolvedAt)
+
+	assert.NoError(t, ticket.TransitTo(TicketStatusReopened))
+	assert.Nil(t, ticket.ResolvedAt)
+
+	assert.NoError(t, ticket.TransitTo(TicketStatusRejected))
+	assert.NoError(t, ticket.TransitTo(TicketStatusClosed))
+	assert.Equal(t, TicketStatusClosed, ticket.Status)
+}
+
+func TestRepairTicket_IllegalTransition(t *testing.T) {
+	ticket := &RepairTicket{Status: TicketStatusSubmitted}
+
+	err := ticket.TransitTo(TicketStatusResolved)
+	assert.ErrorIs(t, err, pkg.ErrIllegalTicketTransition)
+	assert.Equal(t, TicketStatusSubmitted, ticket.Status)
+
+	var transitionErr *pkg.TicketTransitionError
+	assert.ErrorAs(t, err, &transitionErr)
+	assert.Equal(t, "submitted", transitionErr.From)
+	assert.Equal(t, "resolved", transitionErr.To)
+
+	assert.Error(t, ticket.Dispatch(1))
+	assert.Nil(t, ticket.TechnicianID)
+}
+
+func TestFaultCodeCategory(t *testing.T) {
+	category, ok := FaultCodeCategory("BB-0012")
+	assert.True(t, ok)
+	assert.Equal(t, FaultCategoryBroadband, category)
+
+	_, ok = FaultCodeCategory("BB-12")
+	assert.False(t, ok)
+	_, ok = FaultCodeCategory("XX-0012")
+	assert.False(t, ok)
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql/driver"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,10 +16,46 @@ const (
 	UserRoleCityAdmin UserRole = "总管理员"
 )
 
+// 角色等级，等级高的角色拥有等级低的角色的全部权限
+var userRoleLevels = map[UserRole]int{
+	UserRoleEndUser:   1,
+	UserRoleAreaMgr:   2,
+	UserRoleCityAdmin: 3,
+}
+
+// 角色的英文别名，用于命令行等不便输入中文的场景
+var userRoleAliases = map[string]UserRole{
+	"end_user":     UserRoleEndUser,
+	"area_manager": UserRoleAreaMgr,
+	"city_admin":   UserRoleCityAdmin,
+}
+
+// ParseUserRole 按角色名称或英文别名解析角色
+func ParseUserRole(name string) (UserRole, error) {
+	if role, ok := userRoleAliases[name]; ok {
+		return role, nil
+	}
+	if role := UserRole(name); role.Level() > 0 {
+		return role, nil
+	}
+	return "", fmt.Errorf("unknown user role %q, expected one of %s, %s, %s or end_user, area_manager, city_admin",
+		name, UserRoleEndUser, UserRoleAreaMgr, UserRoleCityAdmin)
+}
+
 func (r UserRole) String() string {
 	return string(r)
 }
 
+// Level 角色等级，未知角色返回 0
+func (r UserRole) Level() int {
+	return userRoleLevels[r]
+}
+
+// Includes 角色 r 是否拥有 role 的权限：总管理员 ⊇ 区域管理员 ⊇ 终端用户
+func (r UserRole) Includes(role UserRole) bool {
+	return role.Level() > 0 && r.Level() >= role.Level()
+}
+
 func (r *UserRole) Value() (driver.Value, error) {
 	return string(*r), nil
 }
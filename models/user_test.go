@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserRole_Includes(t *testing.T) {
+	assert.True(t, UserRoleCityAdmin.Includes(UserRoleAreaMgr))
+	assert.True(t, UserRoleCityAdmin.Includes(UserRoleEndUser))
+	assert.True(t, UserRoleAreaMgr.Includes(UserRoleAreaMgr))
+	assert.True(t, UserRoleAreaMgr.Includes(UserRoleEndUser))
+
+	assert.False(t, UserRoleEndUser.Includes(UserRoleAreaMgr))
+	assert.False(t, UserRoleAreaMgr.Includes(UserRoleCityAdmin))
+	assert.False(t, UserRole("unknown").Includes(UserRoleEndUser))
+	assert.False(t, UserRoleCityAdmin.Includes(UserRole("unknown")))
+}
+
+func TestParseUserRole(t *testing.T) {
+	role, err := ParseUserRole("city_admin")
+	assert.NoError(t, err)
+	assert.Equal(t, UserRoleCityAdmin, role)
+
+	role, err = ParseUserRole("区域管理员")
+	assert.NoError(t, err)
+	assert.Equal(t, UserRoleAreaMgr, role)
+
+	_, err = ParseUserRole("root")
+	assert.Error(t, err)
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"telecommunications_repair_hub/consts"
+	"telecommunications_repair_hub/models"
+	"telecommunications_repair_hub/pkg"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+// Claims 访问 token 中携带的用户信息
+type Claims struct {
+	UserID int             `json:"uid"`
+	Phone  string          `json:"phone"`
+	Role   models.UserRole `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// 签名密钥，服务启动时通过 SetSecret 设置
+var secret atomic.Pointer[[]byte]
+
+// SetSecret 设置签名和校验 token 的密钥，来自配置 app.jwt.secret
+func SetSecret(key string) {
+	value := []byte(key)
+	secret.Store(&value)
+}
+
+func signingKey() ([]byte, error) {
+	key := secret.Load()
+	if key == nil || len(*key) == 0 {
+		return nil, errors.New("jwt secret is not configured")
+	}
+	return *key, nil
+}
+
+// IssueToken 为用户签发访问 token
+func IssueToken(user *models.User) (string, error) {
+	if user == nil {
+		return "", errors.New("user is nil")
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		UserID: user.ID,
+		Phone:  user.Phone,
+		Role:   user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    consts.JWT_ISSUER,
+			Subject:   strconv.Itoa(user.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(consts.JWT_EXPIRE)),
+		},
+	}
+
+	key, err := signingKey()
+	if err != nil {
+		return "", err
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to sign token")
+	}
+	return token, nil
+}
+
+// ParseToken 校验并解析访问 token，任何校验失败都返回 pkg.ErrInvalidToken
+func ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		return signingKey()
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(consts.JWT_ISSUER),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, pkg.ErrInvalidToken.Wrap(err)
+	}
+	if !token.Valid {
+		return nil, pkg.ErrInvalidToken
+	}
+	return claims, nil
+}
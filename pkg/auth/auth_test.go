@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"telecommunications_repair_hub/consts"
+	"telecommunications_repair_hub/models"
+	"telecommunications_repair_hub/pkg"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// 测试使用的签名密钥
+const testSecret = "telecommunications_repair_hub_test_secret"
+
+func TestMain(m *testing.M) {
+	SetSecret(testSecret)
+	os.Exit(m.Run())
+}
+
+func TestIssueAndParseToken(t *testing.T) {
+	user := &models.User{ID: 7, Phone: "13800138000", Role: models.UserRoleAreaMgr}
+
+	token, err := IssueToken(user)
+	assert.NoError(t, err)
+
+	claims, err := ParseToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, claims.UserID)
+	assert.Equal(t, "13800138000", claims.Phone)
+	assert.Equal(t, models.UserRoleAreaMgr, claims.Role)
+}
+
+func TestParseToken_Invalid(t *testing.T) {
+	_, err := ParseToken("not-a-token")
+	assert.ErrorIs(t, err, pkg.ErrInvalidToken)
+
+	// 使用其他密钥签名
+	forged, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+		UserID: 1,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    consts.JWT_ISSUER,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}).SignedString([]byte("other-key"))
+	_, err = ParseToken(forged)
+	assert.ErrorIs(t, err, pkg.ErrInvalidToken)
+
+	// 已过期
+	expired, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+		UserID: 1,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    consts.JWT_ISSUER,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+		},
+	}).SignedString([]byte(testSecret))
+	_, err = ParseToken(expired)
+	assert.ErrorIs(t, err, pkg.ErrInvalidToken)
+}
+
+func TestIssueToken_WithoutSecret(t *testing.T) {
+	SetSecret("")
+	defer SetSecret(testSecret)
+
+	_, err := IssueToken(&models.User{ID: 1})
+	assert.Error(t, err)
+}
@@ -1,42 +1,103 @@
 package db
 
 import (
-	"fmt"
+	"context"
 	"telecommunications_repair_hub/config"
-	"telecommunications_repair_hub/models"
 
 	"github.com/pkg/errors"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 type DB struct {
 	*gorm.DB
-	config *config.Config
+	config   *config.Config
+	resolver *resolver
+	// 不注册 dbresolver 的主库连接，迁移需要在同一个连接上加锁和执行
+	primary   *gorm.DB
+	sqlLogger *Logger
 }
 
+// New 按配置的数据库类型连接数据库，不执行迁移，迁移通过 MigrateUp 显式执行
 func New(config *config.Config) (*DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Asia/Shanghai",
-		config.Database.Host, config.Database.User, config.Database.Password, config.Database.Database, config.Database.Port)
-	dbInstance, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	dialector, err := dialector(config.Database)
 	if err != nil {
-		return nil, errors.WithMessage(err, "failed to open database")
+		return nil, err
 	}
-
-	db := &DB{
-		DB:     dbInstance,
-		config: config,
+	sqlLogger := NewLogger(config.Database.Log)
+	dbInstance, err := gorm.Open(dialector, &gorm.Config{
+		// dbresolver 使用相同的配置打开副本，副本不可用时不应导致启动失败，主库在下面单独检查
+		DisableAutomaticPing: true,
+		Logger:               sqlLogger,
+	})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to open %s database", dialector.Name())
+	}
+	sqlDB, err := dbInstance.DB()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get database connection pool")
+	}
+	configurePool(sqlDB, config.Database)
+	if err := sqlDB.Ping(); err != nil {
+		sqlDB.Close()
+		return nil, errors.WithMessagef(err, "failed to connect %s database", dialector.Name())
 	}
 
-	if err := db.Migrate(); err != nil {
-		return nil, errors.WithMessage(err, "failed to migrate database")
+	primary, err := gorm.Open(connDialector(config.Database.Driver, sqlDB), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               sqlLogger,
+	})
+	if err != nil {
+		sqlDB.Close()
+		return nil, errors.WithMessagef(err, "failed to open %s database", dialector.Name())
+	}
+	resolver, err := newResolver(dbInstance, config.Database)
+	if err != nil {
+		sqlDB.Close()
+		return nil, err
 	}
+	if err := dbInstance.Use(queryMetrics{}); err != nil {
+		resolver.Close()
+		sqlDB.Close()
+		return nil, errors.WithMessage(err, "failed to register query metrics")
+	}
+
+	return &DB{
+		DB:        dbInstance,
+		config:    config,
+		resolver:  resolver,
+		primary:   primary,
+		sqlLogger: sqlLogger,
+	}, nil
+}
+
+// ApplyConfig 应用热更新后的 SQL 日志配置
+func (d *DB) ApplyConfig(cfg *config.Config) {
+	d.sqlLogger.ApplyConfig(cfg.Database.Log)
+}
+
+// SQLDebug 是否开启 SQL 调试模式，开启时 http 中间件记录每个请求执行的全部 SQL
+func (d *DB) SQLDebug() bool {
+	return d.sqlLogger.Debug()
+}
 
-	return db, nil
+// Ping 检查数据库连接是否可用
+func (d *DB) Ping(ctx context.Context) error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return errors.WithMessage(err, "failed to get database connection pool")
+	}
+	return sqlDB.PingContext(ctx)
 }
 
-func (d *DB) Migrate() error {
-	return d.AutoMigrate(
-		&models.User{},
-	)
+// Close 关闭主库和副本的连接池
+func (d *DB) Close() error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return errors.WithMessage(err, "failed to get database connection pool")
+	}
+	resolverErr := d.resolver.Close()
+	if err := sqlDB.Close(); err != nil {
+		return err
+	}
+	return resolverErr
 }
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/models"
+	"testing"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestNew_SQLite(t *testing.T) {
+	ctx := context.Background()
+	database, err := New(&config.Config{Database: &config.DatabaseConfig{
+		Driver: DriverSQLite,
+		Path:   filepath.Join(t.TempDir(), "data", "tele.db"),
+	}})
+	require.NoError(t, err)
+	defer database.Close()
+	require.NoError(t, database.Ping(ctx))
+
+	executed, err := database.MigrateUp(ctx, MigrateOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, executed)
+
+	user := &models.User{Username: "张三", Phone: "13800138000", Role: models.UserRoleEndUser}
+	require.NoError(t, database.Create(user).Error)
+
+	var found models.User
+	require.NoError(t, database.First(&found, user.ID).Error)
+	assert.Equal(t, models.UserRoleEndUser, found.Role)
+
+	// 区域编码有唯一索引
+	require.NoError(t, database.Create(&models.Region{Name: "省", Code: "P1", Level: models.RegionLevelProvince, Path: "/"}).Error)
+	err = database.Create(&models.Region{Name: "省", Code: "P1", Level: models.RegionLevelProvince, Path: "/"}).Error
+	assert.True(t, IsUniqueViolation(err))
+
+	_, err = database.MigrateDown(ctx, MigrateOptions{Steps: len(executed)})
+	require.NoError(t, err)
+	assert.False(t, database.Migrator().HasTable(&models.User{}))
+}
+
+// 已执行 0001 的数据库升级后区域编码同样唯一
+func TestMigrateUp_UniqueRegionCode(t *testing.T) {
+	ctx := context.Background()
+	database, err := New(&config.Config{Database: &config.DatabaseConfig{
+		Driver: DriverSQLite,
+		Path:   filepath.Join(t.TempDir(), "tele.db"),
+	}})
+	require.NoError(t, err)
+	defer database.Close()
+
+	_, err = database.MigrateUp(ctx, MigrateOptions{Steps: 1})
+	require.NoError(t, err)
+	require.NoError(t, database.Create(&models.Region{Name: "省", Code: "P1", Level: models.RegionLevelProvince, Path: "/"}).Error)
+
+	executed, err := database.MigrateUp(ctx, MigrateOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, executed)
+	assert.Equal(t, "0002_unique_region_code", executed[0].String())
+
+	err = database.Create(&models.Region{Name: "省", Code: "P1", Level: models.RegionLevelProvince, Path: "/"}).Error
+	assert.True(t, IsUniqueViolation(err))
+
+	_, err = database.MigrateDown(ctx, MigrateOptions{})
+	require.NoError(t, err)
+	assert.NoError(t, database.Create(&models.Region{Name: "省", Code: "P1", Level: models.RegionLevelProvince, Path: "/"}).Error)
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	assert.True(t, IsUniqueViolation(gorm.ErrDuplicatedKey))
+	assert.True(t, IsUniqueViolation(errors.WithMessage(&pgconn.PgError{Code: "23505"}, "insert")))
+	assert.False(t, IsUniqueViolation(&pgconn.PgError{Code: "23503"}))
+	assert.True(t, IsUniqueViolation(&mysqldriver.MySQLError{Number: 1062}))
+	assert.False(t, IsUniqueViolation(&mysqldriver.MySQLError{Number: 1452}))
+	assert.False(t, IsUniqueViolation(gorm.ErrRecordNotFound))
+	assert.False(t, IsUniqueViolation(nil))
+}
+
+func TestPostgresDSN(t *testing.T) {
+	dsn := postgresDSN(&config.DatabaseConfig{
+		Host:             "db.example.com",
+		User:             "tele",
+		Password:         `p'a ss\`,
+		Database:         "tele_repair_hub",
+		TimeZone:         "UTC",
+		SSLMode:          "verify-full",
+		SSLRootCert:      "/etc/ssl/ca.pem",
+		StatementTimeout: 5 * time.Second,
+	})
+
+	assert.Contains(t, dsn, "port='5432'")
+	assert.Contains(t, dsn, `password='p\'a ss\\'`)
+	assert.Contains(t, dsn, "sslmode='verify-full'")
+	assert.Contains(t, dsn, "sslrootcert='/etc/ssl/ca.pem'")
+	assert.Contains(t, dsn, "TimeZone='UTC'")
+	assert.Contains(t, dsn, "statement_timeout='5000'")
+	assert.NotContains(t, dsn, "sslcert=")
+}
+
+func TestMySQLDSN(t *testing.T) {
+	dsn, err := mysqlDSN(&config.DatabaseConfig{
+		Host:             "db.example.com",
+		User:             "tele",
+		Password:         "secret",
+		Database:         "tele_repair_hub",
+		TimeZone:         "Asia/Shanghai",
+		SSLMode:          "disable",
+		StatementTimeout: 2 * time.Second,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(dsn, "tele:secret@tcp(db.example.com:3306)/tele_repair_hub?"))
+	assert.Contains(t, dsn, "multiStatements=true")
+	assert.Contains(t, dsn, "parseTime=true")
+	assert.Contains(t, dsn, "loc=Asia%2FShanghai")
+	assert.Contains(t, dsn, "max_execution_time=2000")
+}
@@ -0,0 +1,263 @@
+package db
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"telecommunications_repair_hub/config"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// 支持的数据库类型
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMySQL    = "mysql"
+)
+
+// sqlite 内存数据库路径
+const sqliteMemory = ":memory:"
+
+// 注册到 mysql 驱动的 TLS 配置名称
+const mysqlTLSConfigName = "tele"
+
+// dialector 按配置的数据库类型创建 gorm 驱动
+func dialector(cfg *config.DatabaseConfig) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case DriverPostgres, "":
+		return postgres.Open(postgresDSN(cfg)), nil
+	case DriverSQLite:
+		dsn, err := sqliteDSN(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.Open(dsn), nil
+	case DriverMySQL:
+		dsn, err := mysqlDSN(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return mysql.Open(dsn), nil
+	default:
+		return nil, errors.Errorf("unsupported database driver %s", cfg.Driver)
+	}
+}
+
+// openConn 按配置打开连接池，只创建连接池不连接数据库
+func openConn(cfg *config.DatabaseConfig) (*sql.DB, error) {
+	var driverName, dsn string
+	var err error
+	switch cfg.Driver {
+	case DriverPostgres, "":
+		driverName, dsn = "pgx", postgresDSN(cfg)
+	case DriverSQLite:
+		driverName = "sqlite3"
+		dsn, err = sqliteDSN(cfg)
+	case DriverMySQL:
+		driverName = "mysql"
+		dsn, err = mysqlDSN(cfg)
+	default:
+		err = errors.Errorf("unsupported database driver %s", cfg.Driver)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sql.Open(driverName, dsn)
+}
+
+// connDialector 使用已打开的连接池创建 gorm 驱动，初始化时不查询数据库版本
+func connDialector(driver string, conn *sql.DB) gorm.Dialector {
+	switch driver {
+	case DriverSQLite:
+		return &sqlite.Dialector{Conn: conn}
+	case DriverMySQL:
+		return mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true})
+	default:
+		return postgres.New(postgres.Config{Conn: conn})
+	}
+}
+
+// configurePool 设置连接池参数，sqlite 内存数据库在全部连接关闭后丢失数据，不回收连接
+func configurePool(conn *sql.DB, cfg *config.DatabaseConfig) {
+	conn.SetMaxOpenConns(cfg.MaxOpenConns)
+	conn.SetMaxIdleConns(cfg.MaxIdleConns)
+	if cfg.Driver == DriverSQLite && cfg.Path == sqliteMemory {
+		return
+	}
+	conn.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	conn.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// replicaDatabaseConfig 副本未设置的字段使用主库的配置
+func replicaDatabaseConfig(primary *config.DatabaseConfig, replica config.ReplicaConfig) *config.DatabaseConfig {
+	cfg := *primary
+	cfg.Replicas, cfg.Tables = nil, nil
+	cfg.Host = valueOrDefault(replica.Host, cfg.Host)
+	cfg.Port = portOrDefault(replica.Port, cfg.Port)
+	cfg.User = valueOrDefault(replica.User, cfg.User)
+	cfg.Password = valueOrDefault(replica.Password, cfg.Password)
+	cfg.Database = valueOrDefault(replica.Database, cfg.Database)
+	cfg.Path = valueOrDefault(replica.Path, cfg.Path)
+	return &cfg
+}
+
+// postgresDSN 生成 key=value 格式的连接串，值中的空格和引号会被转义
+func postgresDSN(cfg *config.DatabaseConfig) string {
+	params := [][2]string{
+		{"host", cfg.Host},
+		{"port", strconv.Itoa(portOrDefault(cfg.Port, 5432))},
+		{"user", cfg.User},
+		{"password", cfg.Password},
+		{"dbname", cfg.Database},
+		{"sslmode", valueOrDefault(cfg.SSLMode, "disable")},
+		{"sslrootcert", cfg.SSLRootCert},
+		{"sslcert", cfg.SSLCert},
+		{"sslkey", cfg.SSLKey},
+		{"TimeZone", cfg.TimeZone},
+	}
+	if cfg.StatementTimeout > 0 {
+		params = append(params, [2]string{"statement_timeout", strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)})
+	}
+
+	pairs := make([]string, 0, len(params))
+	for _, param := range params {
+		if param[1] == "" {
+			continue
+		}
+		value := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(param[1])
+		pairs = append(pairs, fmt.Sprintf("%s='%s'", param[0], value))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// sqliteDSN 文件数据库自动创建目录，内存数据库使用共享缓存，连接池中的连接访问同一个数据库
+func sqliteDSN(cfg *config.DatabaseConfig) (string, error) {
+	if cfg.Path == sqliteMemory {
+		return "file::memory:?cache=shared&_foreign_keys=1", nil
+	}
+	if dir := filepath.Dir(cfg.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", errors.WithMessage(err, "failed to create sqlite directory")
+		}
+	}
+	return "file:" + cfg.Path + "?_foreign_keys=1", nil
+}
+
+func mysqlDSN(cfg *config.DatabaseConfig) (string, error) {
+	dsnConfig := mysqldriver.NewConfig()
+	dsnConfig.User = cfg.User
+	dsnConfig.Passwd = cfg.Password
+	dsnConfig.Net = "tcp"
+	dsnConfig.Addr = net.JoinHostPort(cfg.Host, strconv.Itoa(portOrDefault(cfg.Port, 3306)))
+	dsnConfig.DBName = cfg.Database
+	dsnConfig.ParseTime = true
+	// 迁移文件中包含多条语句
+	dsnConfig.MultiStatements = true
+	dsnConfig.Params = map[string]string{"charset": "utf8mb4"}
+	if cfg.StatementTimeout > 0 {
+		dsnConfig.Params["max_execution_time"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+
+	if cfg.TimeZone != "" {
+		location, err := time.LoadLocation(cfg.TimeZone)
+		if err != nil {
+			return "", errors.WithMessage(err, "failed to load time zone")
+		}
+		dsnConfig.Loc = location
+	}
+
+	if sslMode := valueOrDefault(cfg.SSLMode, "disable"); sslMode != "disable" {
+		tlsConfig, err := mysqlTLSConfig(cfg, sslMode)
+		if err != nil {
+			return "", err
+		}
+		if err := mysqldriver.RegisterTLSConfig(mysqlTLSConfigName, tlsConfig); err != nil {
+			return "", errors.WithMessage(err, "failed to register mysql tls config")
+		}
+		dsnConfig.TLSConfig = mysqlTLSConfigName
+	}
+	return dsnConfig.FormatDSN(), nil
+}
+
+// mysqlTLSConfig 按 postgres 的 sslmode 语义创建 TLS 配置，verify-ca 和 verify-full 校验服务端证书
+func mysqlTLSConfig(cfg *config.DatabaseConfig, sslMode string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         cfg.Host,
+		InsecureSkipVerify: sslMode != "verify-ca" && sslMode != "verify-full",
+	}
+
+	if cfg.SSLRootCert != "" {
+		pem, err := os.ReadFile(cfg.SSLRootCert)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to read ssl root cert")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("failed to parse ssl root cert")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.SSLCert != "" || cfg.SSLKey != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.SSLCert, cfg.SSLKey)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to load ssl client cert")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+func portOrDefault(port, defaultPort int) int {
+	if port == 0 {
+		return defaultPort
+	}
+	return port
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// 唯一约束冲突的错误码
+const (
+	postgresUniqueViolation = "23505"
+	mysqlDuplicateEntry     = 1062
+)
+
+// IsUniqueViolation 判断 err 是否为唯一约束冲突，支持全部数据库类型
+func IsUniqueViolation(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == postgresUniqueViolation
+	}
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDuplicateEntry
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"telecommunications_repair_hub/config"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// 日志中替换敏感参数的值
+const redactedValue = "******"
+
+var (
+	// 占位符，postgres 使用 $1，mysql 和 sqlite 使用 ?
+	placeholderPattern = regexp.MustCompile(`\?|\$\d+`)
+	// 占位符前的比较条件，如 "phone" = $1、`phone` IN (?,?)、SET `phone`=?
+	conditionPattern = regexp.MustCompile("(?i)(\\w+)[`\"]?\\s*(?:=|<>|!=|<=|>=|<|>|\\sLIKE|\\sIN\\s*\\((?:[^,()]*,\\s*)*)\\s*$")
+	// INSERT 语句的列名列表，VALUES 中的占位符按位置对应列名
+	insertPattern = regexp.MustCompile("(?is)^\\s*INSERT\\s+INTO\\s+\\S+\\s*\\(([^)]*)\\)\\s*VALUES")
+)
+
+// 匹配比较条件时只检查占位符前的一段 SQL
+const conditionWindow = 256
+
+// Logger 将 gorm 的日志输出到 slog，日志带有请求 ID，超过阈值的慢查询以 WARN 级别记录，
+// 敏感列的参数值在日志中被替换，调试模式下记录每个请求执行的全部 SQL
+type Logger struct {
+	state *loggerState
+	level gormlogger.LogLevel
+}
+
+// loggerState LogMode 返回的 Logger 共享配置
+type loggerState struct {
+	config atomic.Pointer[config.DatabaseLogConfig]
+}
+
+func NewLogger(cfg *config.DatabaseLogConfig) *Logger {
+	l := &Logger{state: &loggerState{}, level: gormlogger.Warn}
+	l.ApplyConfig(cfg)
+	return l
+}
+
+// ApplyConfig 应用热更新后的配置，cfg 为空时不记录慢查询也不隐藏参数
+func (l *Logger) ApplyConfig(cfg *config.DatabaseLogConfig) {
+	if cfg == nil {
+		cfg = &config.DatabaseLogConfig{}
+	}
+	l.state.config.Store(cfg)
+}
+
+// Debug 是否开启调试模式
+func (l *Logger) Debug() bool {
+	return l.state.config.Load().Debug
+}
+
+func (l *Logger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	return &Logger{state: l.state, level: level}
+}
+
+func (l *Logger) Info(ctx context.Context, msg string, data ...any) {
+	if l.level >= gormlogger.Info {
+		slog.InfoContext(ctx, "[DB] "+fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *Logger) Warn(ctx context.Context, msg string, data ...any) {
+	if l.level >= gormlogger.Warn {
+		slog.WarnContext(ctx, "[DB] "+fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *Logger) Error(ctx context.Context, msg string, data ...any) {
+	if l.level >= gormlogger.Error {
+		slog.ErrorContext(ctx, "[DB] "+fmt.Sprintf(msg, data...))
+	}
+}
+
+// Trace 语句执行完成后调用，fc 返回替换敏感参数后的 SQL
+func (l *Logger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	cfg := l.state.config.Load()
+	trace := sqlTraceFrom(ctx)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := cfg.SlowThreshold > 0 && elapsed > cfg.SlowThreshold
+	if trace == nil && !(failed && l.level >= gormlogger.Error) && !(slow && l.level >= gormlogger.Warn) && l.level < gormlogger.Info {
+		return
+	}
+
+	sql, rows := fc()
+	if trace != nil {
+		trace.add(sql, rows, elapsed, err)
+	}
+	switch {
+	case failed && l.level >= gormlogger.Error:
+		slog.ErrorContext(ctx, "[DB] Query failed", "SQL", sql, "Rows", rows, "Duration", elapsed, "Error", err)
+	case slow && l.level >= gormlogger.Warn:
+		slog.WarnContext(ctx, "[DB] Slow query", "SQL", sql, "Rows", rows, "Duration", elapsed, "Threshold", cfg.SlowThreshold)
+	case l.level >= gormlogger.Info:
+		slog.InfoContext(ctx, "[DB] Query", "SQL", sql, "Rows", rows, "Duration", elapsed)
+	}
+}
+
+// ParamsFilter 替换敏感列的参数值，gorm 在生成日志中的 SQL 前调用
+func (l *Logger) ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any) {
+	columns := l.state.config.Load().RedactColumns
+	if len(columns) == 0 || len(params) == 0 {
+		return sql, params
+	}
+	return sql, redactParams(sql, params, columns)
+}
+
+// redactParams 按占位符前的列名找到敏感参数，返回替换后的参数副本
+func redactParams(sql string, params []any, columns []string) []any {
+	sensitive := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		sensitive[strings.ToLower(column)] = true
+	}
+
+	var insertColumns []string
+	valuesStart := -1
+	if match := insertPattern.FindStringSubmatchIndex(sql); match != nil {
+		for _, column := range strings.Split(sql[match[2]:match[3]], ",") {
+			insertColumns = append(insertColumns, strings.ToLower(strings.Trim(strings.TrimSpace(column), "`\"")))
+		}
+		valuesStart = match[1]
+	}
+
+	redacted := append([]any(nil), params...)
+	for i, loc := range placeholderPattern.FindAllStringIndex(sql, -1) {
+		index := i
+		if placeholder := sql[loc[0]:loc[1]]; placeholder != "?" {
+			index, _ = strconv.Atoi(placeholder[1:])
+			index--
+		}
+		if index < 0 || index >= len(params) {
+			continue
+		}
+
+		column := ""
+		if match := conditionPattern.FindStringSubmatch(sql[max(0, loc[0]-conditionWindow):loc[0]]); match != nil {
+			column = strings.ToLower(match[1])
+		} else if valuesStart >= 0 && loc[0] >= valuesStart {
+			column = insertColumns[index%len(insertColumns)]
+		}
+		if !sensitive[column] {
+			continue
+		}
+
+		redacted[index] = redactedValue
+	}
+	return redacted
+}
+
+type sqlTraceKey struct{}
+
+// SQLTrace 一个请求执行的全部 SQL
+type SQLTrace struct {
+	mu         sync.Mutex
+	statements []TracedStatement
+}
+
+type TracedStatement struct {
+	SQL      string
+	Rows     int64
+	Duration time.Duration
+	Error    error
+}
+
+// WithSQLTrace 在 ctx 中开始记录 SQL，使用返回的 ctx 执行的语句都会记录到 SQLTrace
+func WithSQLTrace(ctx context.Context) (context.Context, *SQLTrace) {
+	trace := &SQLTrace{}
+	return context.WithValue(ctx, sqlTraceKey{}, trace), trace
+}
+
+func sqlTraceFrom(ctx context.Context) *SQLTrace {
+	if ctx == nil {
+		return nil
+	}
+	trace, _ := ctx.Value(sqlTraceKey{}).(*SQLTrace)
+	return trace
+}
+
+func (t *SQLTrace) add(sql string, rows int64, duration time.Duration, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.statements = append(t.statements, TracedStatement{SQL: sql, Rows: rows, Duration: duration, Error: err})
+}
+
+// Statements 按执行顺序返回记录的语句
+func (t *SQLTrace) Statements() []TracedStatement {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TracedStatement(nil), t.statements...)
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"path/filepath"
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactParams(t *testing.T) {
+	columns := []string{"phone", "password"}
+	tests := []struct {
+		name   string
+		sql    string
+		params []any
+		want   []any
+	}{
+		{
+			name:   "postgres condition",
+			sql:    `SELECT * FROM "tele_user" WHERE "tele_user"."phone" = $2 AND "tele_user"."id" = $1`,
+			params: []any{1, "13800138000"},
+			want:   []any{1, redactedValue},
+		},
+		{
+			name:   "insert values",
+			sql:    "INSERT INTO `tele_user` (`username`,`phone`) VALUES (?,?),(?,?) RETURNING `id`",
+			params: []any{"张三", "13800138000", "李四", "13900139000"},
+			want:   []any{"张三", redactedValue, "李四", redactedValue},
+		},
+		{
+			name:   "update set",
+			sql:    "UPDATE `tele_user` SET `phone`=?,`updated_at`=? WHERE `id` = ?",
+			params: []any{"13800138000", "2024-01-01", 1},
+			want:   []any{redactedValue, "2024-01-01", 1},
+		},
+		{
+			name:   "in list",
+			sql:    "SELECT * FROM `tele_user` WHERE `id` IN (?,?) AND phone IN (?,?)",
+			params: []any{1, 2, "13800138000", "13900139000"},
+			want:   []any{1, 2, redactedValue, redactedValue},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := append([]any(nil), tt.params...)
+			assert.Equal(t, tt.want, redactParams(tt.sql, params, columns))
+			assert.Equal(t, tt.params, params)
+		})
+	}
+}
+
+func TestLogger_SlowQueryAndTrace(t *testing.T) {
+	var buf bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer slog.SetDefault(defaultLogger)
+
+	database, err := New(&config.Config{Database: &config.DatabaseConfig{
+		Driver: DriverSQLite,
+		Path:   filepath.Join(t.TempDir(), "tele.db"),
+		Log:    &config.DatabaseLogConfig{SlowThreshold: time.Nanosecond, RedactColumns: []string{"phone"}},
+	}})
+	require.NoError(t, err)
+	defer database.Close()
+	_, err = database.MigrateUp(context.Background(), MigrateOptions{})
+	require.NoError(t, err)
+
+	ctx, trace := WithSQLTrace(context.Background())
+	user := &models.User{Username: "张三", Phone: "13800138000", Role: models.UserRoleEndUser}
+	require.NoError(t, database.WithContext(ctx).Create(user).Error)
+
+	assert.Contains(t, buf.String(), "[DB] Slow query")
+	assert.Contains(t, buf.String(), "张三")
+	assert.NotContains(t, buf.String(), "13800138000")
+
+	statements := trace.Statements()
+	require.Len(t, statements, 1)
+	assert.Contains(t, statements[0].SQL, redactedValue)
+	assert.Equal(t, int64(1), statements[0].Rows)
+
+	// 热更新关闭慢查询日志
+	buf.Reset()
+	database.ApplyConfig(&config.Config{Database: &config.DatabaseConfig{Log: &config.DatabaseLogConfig{Debug: true}}})
+	require.NoError(t, database.First(&models.User{}).Error)
+	assert.Empty(t, buf.String())
+	assert.True(t, database.SQLDebug())
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+// QueryDuration 按表和操作统计语句执行时间，operation 为 create、query、update、delete、row 或 raw
+var QueryDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query latency in seconds",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	},
+	[]string{"table", "operation"},
+)
+
+// 原生 SQL 等无法确定表名的语句使用的 table 标签
+const unknownTable = "unknown"
+
+const queryStartKey = "tele:query_start"
+
+// queryMetrics 记录每条语句执行时间的 gorm 插件
+type queryMetrics struct{}
+
+func (queryMetrics) Name() string {
+	return "tele:query_metrics"
+}
+
+func (m queryMetrics) Initialize(db *gorm.DB) error {
+	const before, after = "tele:query_metrics_before", "tele:query_metrics_after"
+	callback := db.Callback()
+	for _, err := range []error{
+		callback.Create().Before("gorm:create").Register(before, m.start),
+		callback.Create().After("gorm:create").Register(after, m.observe("create")),
+		callback.Query().Before("gorm:query").Register(before, m.start),
+		callback.Query().After("gorm:query").Register(after, m.observe("query")),
+		callback.Update().Before("gorm:update").Register(before, m.start),
+		callback.Update().After("gorm:update").Register(after, m.observe("update")),
+		callback.Delete().Before("gorm:delete").Register(before, m.start),
+		callback.Delete().After("gorm:delete").Register(after, m.observe("delete")),
+		callback.Row().Before("gorm:row").Register(before, m.start),
+		callback.Row().After("gorm:row").Register(after, m.observe("row")),
+		callback.Raw().Before("gorm:raw").Register(before, m.start),
+		callback.Raw().After("gorm:raw").Register(after, m.observe("raw")),
+	} {
+		if err != nil {
+			return errors.WithMessage(err, "failed to register query metrics callbacks")
+		}
+	}
+	return nil
+}
+
+func (queryMetrics) start(tx *gorm.DB) {
+	tx.InstanceSet(queryStartKey, time.Now())
+}
+
+func (queryMetrics) observe(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		value, ok := tx.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		QueryDuration.WithLabelValues(statementTable(tx.Statement), operation).Observe(time.Since(value.(time.Time)).Seconds())
+	}
+}
+
+func statementTable(stmt *gorm.Statement) string {
+	if stmt.Table != "" {
+		return stmt.Table
+	}
+	if stmt.Schema != nil {
+		return stmt.Schema.Table
+	}
+	return unknownTable
+}
+
+// StatsCollector 导出主库和副本的连接池状态，db_name 标签为 primary 或副本名称
+func (d *DB) StatsCollector() prometheus.Collector {
+	statsCollectors := multiCollector{collectors.NewDBStatsCollector(d.resolver.primary, primarySource)}
+	for _, replica := range d.resolver.replicas {
+		statsCollectors = append(statsCollectors, collectors.NewDBStatsCollector(replica.conn, replica.name))
+	}
+	return statsCollectors
+}
+
+type multiCollector []prometheus.Collector
+
+func (c multiCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c {
+		collector.Describe(ch)
+	}
+}
+
+func (c multiCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c {
+		collector.Collect(ch)
+	}
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"path/filepath"
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/models"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func querySampleCount(t *testing.T, table, operation string) uint64 {
+	t.Helper()
+	var metric dto.Metric
+	require.NoError(t, QueryDuration.WithLabelValues(table, operation).(prometheus.Histogram).Write(&metric))
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestQueryMetrics(t *testing.T) {
+	database, err := New(&config.Config{Database: &config.DatabaseConfig{
+		Driver:       DriverSQLite,
+		Path:         filepath.Join(t.TempDir(), "tele.db"),
+		MaxOpenConns: 4,
+		MaxIdleConns: 2,
+	}})
+	require.NoError(t, err)
+	defer database.Close()
+	require.NoError(t, database.Exec("CREATE TABLE tele_user (id INTEGER PRIMARY KEY, username TEXT, phone TEXT, role TEXT, created_at DATETIME, updated_at DATETIME, deleted_at DATETIME)").Error)
+
+	creates := querySampleCount(t, "tele_user", "create")
+	queries := querySampleCount(t, "tele_user", "query")
+	require.NoError(t, database.Create(&models.User{Username: "张三", Phone: "13800138000", Role: models.UserRoleEndUser}).Error)
+	var users []models.User
+	require.NoError(t, database.Find(&users).Error)
+
+	assert.Equal(t, creates+1, querySampleCount(t, "tele_user", "create"))
+	assert.Equal(t, queries+1, querySampleCount(t, "tele_user", "query"))
+	assert.Positive(t, querySampleCount(t, unknownTable, "raw"))
+}
+
+func TestStatsCollector(t *testing.T) {
+	database, err := New(&config.Config{Database: &config.DatabaseConfig{
+		Driver:       DriverSQLite,
+		Path:         filepath.Join(t.TempDir(), "tele.db"),
+		MaxOpenConns: 4,
+	}})
+	require.NoError(t, err)
+	defer database.Close()
+
+	sqlDB, err := database.DB.DB()
+	require.NoError(t, err)
+	assert.Equal(t, 4, sqlDB.Stats().MaxOpenConnections)
+	assert.Equal(t, 1, testutil.CollectAndCount(database.StatsCollector(), "go_sql_max_open_connections"))
+}
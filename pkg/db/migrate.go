@@ -0,0 +1,375 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// migrationFiles 按数据库类型存放的 SQL 迁移，文件名为 <版本>_<名称>.up.sql 和 <版本>_<名称>.down.sql
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// 迁移时持有的 Postgres advisory lock，多个实例同时启动时只有一个实例执行迁移
+const migrationLockID = 7_238_914_016
+
+const schemaMigrationsTable = "schema_migrations"
+
+// Migration 单个版本的迁移
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	// 为空时该迁移不能回滚
+	Down func(tx *gorm.DB) error
+	// SQL 迁移的语句，dry-run 时输出
+	UpSQL   string
+	DownSQL string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// MigrateOptions 迁移选项
+type MigrateOptions struct {
+	// 只输出将要执行的迁移，不修改数据库
+	DryRun bool
+	// 执行的迁移数量，为 0 时 up 执行全部未执行的迁移，down 回滚最近一个迁移
+	Steps int
+	// dry-run 时输出 SQL 的位置
+	Output io.Writer
+}
+
+// MigrationStatus 迁移的执行状态
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	// 数据库中已执行但当前程序中不存在的迁移，通常是数据库被更新版本的程序迁移过
+	Missing bool
+}
+
+type schemaMigration struct {
+	Version   int64     `gorm:"column:version;primaryKey"`
+	Name      string    `gorm:"column:name"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+func (schemaMigration) TableName() string {
+	return schemaMigrationsTable
+}
+
+var goMigrations []Migration
+
+// MigrateUp 执行未执行的迁移
+func (d *DB) MigrateUp(ctx context.Context, opts MigrateOptions) ([]Migration, error) {
+	migrator, err := NewMigrator(d.primary)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Up(ctx, opts)
+}
+
+// MigrateDown 回滚已执行的迁移
+func (d *DB) MigrateDown(ctx context.Context, opts MigrateOptions) ([]Migration, error) {
+	migrator, err := NewMigrator(d.primary)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Down(ctx, opts)
+}
+
+// MigrateStatus 返回全部迁移的执行状态
+func (d *DB) MigrateStatus(ctx context.Context) ([]MigrationStatus, error) {
+	migrator, err := NewMigrator(d.primary)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Status(ctx)
+}
+
+// RegisterMigration 注册 Go 迁移，用于 SQL 难以完成的数据回填等，版本号与 SQL 迁移共用
+func RegisterMigration(migration Migration) {
+	goMigrations = append(goMigrations, migration)
+}
+
+// Migrator 执行版本化迁移
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator 加载当前数据库类型的 SQL 迁移和注册的 Go 迁移
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	if _, err := fs.Stat(migrationFiles, path.Join("migrations", dialect)); err != nil {
+		return nil, errors.Errorf("no migrations for database %s", dialect)
+	}
+	return newMigrator(db, migrationFiles, path.Join("migrations", dialect), goMigrations)
+}
+
+func newMigrator(db *gorm.DB, fsys fs.FS, dir string, extra []Migration) (*Migrator, error) {
+	migrations, err := loadSQLMigrations(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	migrations = append(migrations, extra...)
+	slices.SortFunc(migrations, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, errors.Errorf("duplicate migration version %d", migrations[i].Version)
+		}
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func loadSQLMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read migrations")
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, errors.Errorf("invalid migration file name %s", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to read migration %s", entry.Name())
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, errors.Errorf("migration version %d has different names %s and %s", version, migration.Name, match[2])
+		}
+
+		sql := string(content)
+		if match[3] == "up" {
+			migration.UpSQL = sql
+			migration.Up = execSQL(sql)
+		} else {
+			migration.DownSQL = sql
+			migration.Down = execSQL(sql)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == nil {
+			return nil, errors.Errorf("migration %s has no up file", migration)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	return migrations, nil
+}
+
+// execSQL 整个文件作为一次 Exec 执行，文件中可以包含多条语句
+func execSQL(sql string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		return tx.Exec(sql).Error
+	}
+}
+
+// Up 按版本顺序执行未执行的迁移，每个迁移在单独的事务中执行，返回执行的迁移
+func (m *Migrator) Up(ctx context.Context, opts MigrateOptions) ([]Migration, error) {
+	var executed []Migration
+	err := m.withLock(ctx, opts.DryRun, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if opts.Steps > 0 && len(executed) >= opts.Steps {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			if opts.DryRun {
+				printMigration(opts.Output, migration, "up", migration.UpSQL)
+			} else if err := m.run(conn, migration, migration.Up, func(tx *gorm.DB) error {
+				return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}).Error
+			}); err != nil {
+				return errors.WithMessagef(err, "failed to apply migration %s", migration)
+			}
+			executed = append(executed, migration)
+		}
+		return nil
+	})
+	return executed, err
+}
+
+// Down 按版本倒序回滚已执行的迁移，返回回滚的迁移
+func (m *Migrator) Down(ctx context.Context, opts MigrateOptions) ([]Migration, error) {
+	steps := opts.Steps
+	if steps <= 0 {
+		steps = 1
+	}
+
+	var executed []Migration
+	err := m.withLock(ctx, opts.DryRun, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		versions := make([]int64, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		slices.Sort(versions)
+		slices.Reverse(versions)
+
+		for _, version := range versions[:min(steps, len(versions))] {
+			index := slices.IndexFunc(m.migrations, func(migration Migration) bool { return migration.Version == version })
+			if index < 0 {
+				return errors.Errorf("migration %04d_%s is not known to this build", version, applied[version].Name)
+			}
+			migration := m.migrations[index]
+			if migration.Down == nil {
+				return errors.Errorf("migration %s cannot be rolled back", migration)
+			}
+
+			if opts.DryRun {
+				printMigration(opts.Output, migration, "down", migration.DownSQL)
+			} else if err := m.run(conn, migration, migration.Down, func(tx *gorm.DB) error {
+				return tx.Delete(&schemaMigration{Version: migration.Version}).Error
+			}); err != nil {
+				return errors.WithMessagef(err, "failed to roll back migration %s", migration)
+			}
+			executed = append(executed, migration)
+		}
+		return nil
+	})
+	return executed, err
+}
+
+// Status 返回全部迁移的执行状态，按版本排序
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(m.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &row.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, row := range applied {
+		statuses = append(statuses, MigrationStatus{
+			Version:   row.Version,
+			Name:      row.Name,
+			Applied:   true,
+			AppliedAt: &row.AppliedAt,
+			Missing:   true,
+		})
+	}
+	slices.SortFunc(statuses, func(a, b MigrationStatus) int { return cmp.Compare(a.Version, b.Version) })
+	return statuses, nil
+}
+
+// run 在事务中执行迁移并更新 schema_migrations
+func (m *Migrator) run(conn *gorm.DB, migration Migration, fn func(tx *gorm.DB) error, record func(tx *gorm.DB) error) error {
+	start := time.Now()
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return record(tx)
+	})
+	if err != nil {
+		return err
+	}
+	slog.Info("[Migrate] Migration done", "Migration", migration.String(), "Duration", time.Since(start))
+	return nil
+}
+
+// applied 已执行的迁移，schema_migrations 表不存在时返回空
+func (m *Migrator) applied(conn *gorm.DB) (map[int64]schemaMigration, error) {
+	applied := map[int64]schemaMigration{}
+	if !conn.Migrator().HasTable(schemaMigrationsTable) {
+		return applied, nil
+	}
+
+	var rows []schemaMigration
+	if err := conn.Order("version").Find(&rows).Error; err != nil {
+		return nil, errors.WithMessage(err, "failed to read schema migrations")
+	}
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// withLock Postgres 在同一连接上持有 advisory lock 后执行 fn，dryRun 为 false 时创建 schema_migrations 表
+func (m *Migrator) withLock(ctx context.Context, dryRun bool, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if conn.Dialector.Name() == "postgres" {
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+				return errors.WithMessage(err, "failed to acquire migration lock")
+			}
+			defer func() {
+				if err := conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockID).Error; err != nil {
+					slog.Error("[Migrate] Release lock", "Error", err)
+				}
+			}()
+		}
+
+		if dryRun {
+			return fn(conn)
+		}
+		if err := conn.Exec(`CREATE TABLE IF NOT EXISTS ` + schemaMigrationsTable + ` (
+	version BIGINT PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	applied_at TIMESTAMP NOT NULL
+)`).Error; err != nil {
+			return errors.WithMessage(err, "failed to create schema migrations table")
+		}
+		return fn(conn)
+	})
+}
+
+func printMigration(w io.Writer, migration Migration, direction string, sql string) {
+	if w == nil {
+		return
+	}
+	if sql == "" {
+		fmt.Fprintf(w, "-- %s (%s): go migration\n\n", migration, direction)
+		return
+	}
+	fmt.Fprintf(w, "-- %s (%s)\n%s\n", migration, direction, sql)
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var testMigrations = fstest.MapFS{
+	"migrations/0001_create_device.up.sql":   {Data: []byte("CREATE TABLE device (id INTEGER PRIMARY KEY, name TEXT NOT NULL);")},
+	"migrations/0001_create_device.down.sql": {Data: []byte("DROP TABLE device;")},
+	"migrations/0002_add_device_model.up.sql": {Data: []byte(
+		"ALTER TABLE device ADD COLUMN model TEXT;\nCREATE INDEX idx_device_model ON device (model);")},
+	"migrations/0002_add_device_model.down.sql": {Data: []byte("DROP INDEX idx_device_model;\nALTER TABLE device DROP COLUMN model;")},
+}
+
+func newTestMigrator(t *testing.T, extra ...Migration) (*Migrator, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "migrate.db")), &gorm.Config{})
+	require.NoError(t, err)
+	migrator, err := newMigrator(db, testMigrations, "migrations", extra)
+	require.NoError(t, err)
+	return migrator, db
+}
+
+func TestMigrator_UpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	migrator, db := newTestMigrator(t)
+
+	executed, err := migrator.Up(ctx, MigrateOptions{Steps: 1})
+	require.NoError(t, err)
+	require.Len(t, executed, 1)
+	assert.Equal(t, "0001_create_device", executed[0].String())
+
+	executed, err = migrator.Up(ctx, MigrateOptions{})
+	require.NoError(t, err)
+	require.Len(t, executed, 1)
+	assert.True(t, db.Migrator().HasColumn("device", "model"))
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	for _, status := range statuses {
+		assert.True(t, status.Applied)
+		assert.NotNil(t, status.AppliedAt)
+	}
+
+	// 再次执行没有未执行的迁移
+	executed, err = migrator.Up(ctx, MigrateOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, executed)
+
+	executed, err = migrator.Down(ctx, MigrateOptions{})
+	require.NoError(t, err)
+	require.Len(t, executed, 1)
+	assert.Equal(t, int64(2), executed[0].Version)
+	assert.False(t, db.Migrator().HasColumn("device", "model"))
+
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+}
+
+func TestMigrator_DryRun(t *testing.T) {
+	ctx := context.Background()
+	migrator, db := newTestMigrator(t)
+
+	out := &bytes.Buffer{}
+	executed, err := migrator.Up(ctx, MigrateOptions{DryRun: true, Output: out})
+	require.NoError(t, err)
+	assert.Len(t, executed, 2)
+	assert.Contains(t, out.String(), "-- 0001_create_device (up)")
+	assert.Contains(t, out.String(), "CREATE INDEX idx_device_model")
+
+	// dry-run 不修改数据库
+	assert.False(t, db.Migrator().HasTable("device"))
+	assert.False(t, db.Migrator().HasTable(schemaMigrationsTable))
+}
+
+func TestMigrator_FailedMigrationRollsBack(t *testing.T) {
+	ctx := context.Background()
+	migrator, db := newTestMigrator(t, Migration{
+		Version: 3,
+		Name:    "backfill_device_model",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Exec("UPDATE device SET model = 'ONT'").Error; err != nil {
+				return err
+			}
+			return tx.Exec("UPDATE missing_table SET x = 1").Error
+		},
+	})
+
+	_, err := migrator.Up(ctx, MigrateOptions{})
+	assert.ErrorContains(t, err, "0003_backfill_device_model")
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[1].Applied)
+	assert.False(t, statuses[2].Applied)
+	assert.True(t, db.Migrator().HasColumn("device", "model"))
+
+}
+
+func TestMigrator_StatusReportsMissing(t *testing.T) {
+	ctx := context.Background()
+	migrator, db := newTestMigrator(t)
+	_, err := migrator.Up(ctx, MigrateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&schemaMigration{Version: 9, Name: "from_newer_build"}).Error)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	assert.True(t, statuses[2].Missing)
+
+	_, err = migrator.Down(ctx, MigrateOptions{})
+	assert.ErrorContains(t, err, "not known to this build")
+}
+
+func TestNewMigrator_EmbeddedPostgres(t *testing.T) {
+	migrations, err := loadSQLMigrations(migrationFiles, "migrations/postgres")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, "0001_init", migrations[0].String())
+	assert.NotNil(t, migrations[0].Down)
+}
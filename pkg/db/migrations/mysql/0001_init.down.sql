@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS tele_repair_ticket;
+DROP TABLE IF EXISTS tele_user_region;
+DROP TABLE IF EXISTS tele_region;
+DROP TABLE IF EXISTS tele_user;
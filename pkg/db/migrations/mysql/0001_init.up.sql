@@ -0,0 +1,56 @@
+-- 初始表结构，与 postgres/0001_init.up.sql 对应，MySQL 的 DDL 不能在事务中回滚
+CREATE TABLE IF NOT EXISTS tele_user (
+    id         bigint       NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    username   varchar(255) NOT NULL,
+    phone      varchar(32)  NOT NULL,
+    role       varchar(32)  NOT NULL,
+    created_at datetime(3)  NOT NULL,
+    updated_at datetime(3)  NOT NULL
+) DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS tele_region (
+    id         bigint       NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    parent_id  bigint,
+    name       varchar(255) NOT NULL,
+    code       varchar(64)  NOT NULL,
+    level      bigint       NOT NULL,
+    path       varchar(255) NOT NULL,
+    created_at datetime(3)  NOT NULL,
+    updated_at datetime(3)  NOT NULL,
+    INDEX idx_tele_region_parent_id (parent_id),
+    INDEX idx_tele_region_code (code),
+    INDEX idx_tele_region_path (path)
+) DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS tele_user_region (
+    id         bigint      NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    user_id    bigint      NOT NULL,
+    region_id  bigint      NOT NULL,
+    created_at datetime(3) NOT NULL,
+    INDEX idx_tele_user_region_user_id (user_id),
+    INDEX idx_tele_user_region_region_id (region_id)
+) DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS tele_repair_ticket (
+    id            bigint       NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    reporter_id   bigint       NOT NULL,
+    address       varchar(512) NOT NULL,
+    region_id     bigint       NOT NULL,
+    category      varchar(32)  NOT NULL,
+    description   text         NOT NULL,
+    priority      bigint       NOT NULL DEFAULT 2,
+    status        varchar(32)  NOT NULL,
+    technician_id bigint,
+    remark        text,
+    accepted_at   datetime(3),
+    dispatched_at datetime(3),
+    started_at    datetime(3),
+    resolved_at   datetime(3),
+    closed_at     datetime(3),
+    created_at    datetime(3)  NOT NULL,
+    updated_at    datetime(3)  NOT NULL,
+    INDEX idx_tele_repair_ticket_reporter_id (reporter_id),
+    INDEX idx_tele_repair_ticket_region_id (region_id),
+    INDEX idx_tele_repair_ticket_status (status),
+    INDEX idx_tele_repair_ticket_technician_id (technician_id)
+) DEFAULT CHARSET = utf8mb4;
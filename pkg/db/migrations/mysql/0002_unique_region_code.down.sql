@@ -0,0 +1 @@
+ALTER TABLE tele_region DROP INDEX idx_tele_region_code, ADD INDEX idx_tele_region_code (code);
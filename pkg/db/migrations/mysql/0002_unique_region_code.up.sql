@@ -0,0 +1,2 @@
+-- 区域编码唯一，与 postgres/0002_unique_region_code.up.sql 对应
+ALTER TABLE tele_region DROP INDEX idx_tele_region_code, ADD UNIQUE INDEX idx_tele_region_code (code);
@@ -0,0 +1,56 @@
+-- 初始表结构，与此前 AutoMigrate 创建的表结构一致，已有数据库执行时不会重复创建
+CREATE TABLE IF NOT EXISTS tele_user (
+    id         bigserial PRIMARY KEY,
+    username   text        NOT NULL,
+    phone      text        NOT NULL,
+    role       text        NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tele_region (
+    id         bigserial PRIMARY KEY,
+    parent_id  bigint,
+    name       text        NOT NULL,
+    code       text        NOT NULL,
+    level      bigint      NOT NULL,
+    path       text        NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tele_region_parent_id ON tele_region (parent_id);
+CREATE INDEX IF NOT EXISTS idx_tele_region_code ON tele_region (code);
+CREATE INDEX IF NOT EXISTS idx_tele_region_path ON tele_region (path);
+
+CREATE TABLE IF NOT EXISTS tele_user_region (
+    id         bigserial PRIMARY KEY,
+    user_id    bigint      NOT NULL,
+    region_id  bigint      NOT NULL,
+    created_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tele_user_region_user_id ON tele_user_region (user_id);
+CREATE INDEX IF NOT EXISTS idx_tele_user_region_region_id ON tele_user_region (region_id);
+
+CREATE TABLE IF NOT EXISTS tele_repair_ticket (
+    id            bigserial PRIMARY KEY,
+    reporter_id   bigint      NOT NULL,
+    address       text        NOT NULL,
+    region_id     bigint      NOT NULL,
+    category      text        NOT NULL,
+    description   text        NOT NULL,
+    priority      bigint      NOT NULL DEFAULT 2,
+    status        text        NOT NULL,
+    technician_id bigint,
+    remark        text,
+    accepted_at   timestamptz,
+    dispatched_at timestamptz,
+    started_at    timestamptz,
+    resolved_at   timestamptz,
+    closed_at     timestamptz,
+    created_at    timestamptz NOT NULL,
+    updated_at    timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tele_repair_ticket_reporter_id ON tele_repair_ticket (reporter_id);
+CREATE INDEX IF NOT EXISTS idx_tele_repair_ticket_region_id ON tele_repair_ticket (region_id);
+CREATE INDEX IF NOT EXISTS idx_tele_repair_ticket_status ON tele_repair_ticket (status);
+CREATE INDEX IF NOT EXISTS idx_tele_repair_ticket_technician_id ON tele_repair_ticket (technician_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tele_region_code;
+CREATE INDEX idx_tele_region_code ON tele_region (code);
@@ -0,0 +1,3 @@
+-- 区域编码唯一，已有重复编码时迁移失败，需要先处理重复数据
+DROP INDEX IF EXISTS idx_tele_region_code;
+CREATE UNIQUE INDEX idx_tele_region_code ON tele_region (code);
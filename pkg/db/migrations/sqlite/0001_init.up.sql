@@ -0,0 +1,56 @@
+-- 初始表结构，与 postgres/0001_init.up.sql 对应
+CREATE TABLE IF NOT EXISTS tele_user (
+    id         integer PRIMARY KEY AUTOINCREMENT,
+    username   text     NOT NULL,
+    phone      text     NOT NULL,
+    role       text     NOT NULL,
+    created_at datetime NOT NULL,
+    updated_at datetime NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tele_region (
+    id         integer PRIMARY KEY AUTOINCREMENT,
+    parent_id  integer,
+    name       text     NOT NULL,
+    code       text     NOT NULL,
+    level      integer  NOT NULL,
+    path       text     NOT NULL,
+    created_at datetime NOT NULL,
+    updated_at datetime NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tele_region_parent_id ON tele_region (parent_id);
+CREATE INDEX IF NOT EXISTS idx_tele_region_code ON tele_region (code);
+CREATE INDEX IF NOT EXISTS idx_tele_region_path ON tele_region (path);
+
+CREATE TABLE IF NOT EXISTS tele_user_region (
+    id         integer PRIMARY KEY AUTOINCREMENT,
+    user_id    integer  NOT NULL,
+    region_id  integer  NOT NULL,
+    created_at datetime NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tele_user_region_user_id ON tele_user_region (user_id);
+CREATE INDEX IF NOT EXISTS idx_tele_user_region_region_id ON tele_user_region (region_id);
+
+CREATE TABLE IF NOT EXISTS tele_repair_ticket (
+    id            integer PRIMARY KEY AUTOINCREMENT,
+    reporter_id   integer  NOT NULL,
+    address       text     NOT NULL,
+    region_id     integer  NOT NULL,
+    category      text     NOT NULL,
+    description   text     NOT NULL,
+    priority      integer  NOT NULL DEFAULT 2,
+    status        text     NOT NULL,
+    technician_id integer,
+    remark        text,
+    accepted_at   datetime,
+    dispatched_at datetime,
+    started_at    datetime,
+    resolved_at   datetime,
+    closed_at     datetime,
+    created_at    datetime NOT NULL,
+    updated_at    datetime NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tele_repair_ticket_reporter_id ON tele_repair_ticket (reporter_id);
+CREATE INDEX IF NOT EXISTS idx_tele_repair_ticket_region_id ON tele_repair_ticket (region_id);
+CREATE INDEX IF NOT EXISTS idx_tele_repair_ticket_status ON tele_repair_ticket (status);
+CREATE INDEX IF NOT EXISTS idx_tele_repair_ticket_technician_id ON tele_repair_ticket (technician_id);
@@ -0,0 +1,3 @@
+-- 区域编码唯一，与 postgres/0002_unique_region_code.up.sql 对应
+DROP INDEX IF EXISTS idx_tele_region_code;
+CREATE UNIQUE INDEX idx_tele_region_code ON tele_region (code);
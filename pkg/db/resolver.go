@@ -0,0 +1,290 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"telecommunications_repair_hub/config"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// 主库在监控指标中的名称，事务中的查询都使用主库
+const primarySource = "primary"
+
+// 健康检查中单个副本的 ping 超时时间，与检查间隔无关，避免启动时等待不可用的副本过久
+const replicaPingTimeout = 2 * time.Second
+
+// ResolverQueryCounter 按数据源统计查询次数，source 为 primary 或副本名称
+var ResolverQueryCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "db_resolver_queries_total",
+		Help: "Total number of database queries by source",
+	},
+	[]string{"source", "operation"},
+)
+
+// ReplicaHealthy 副本健康状态，1 为健康
+var ReplicaHealthy = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "db_replica_healthy",
+		Help: "Whether the database replica is healthy",
+	},
+	[]string{"replica"},
+)
+
+type replica struct {
+	name    string
+	conn    *sql.DB
+	healthy atomic.Bool
+}
+
+// resolver 读写分离，查询按策略分配到健康的副本，定期检查副本并剔除不可用的副本
+type resolver struct {
+	driver   string
+	primary  *sql.DB
+	replicas []*replica
+	byConn   map[gorm.ConnPool]*replica
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// newResolver 向 dbInstance 注册 dbresolver，未配置副本时读写都使用主库
+func newResolver(dbInstance *gorm.DB, cfg *config.DatabaseConfig) (*resolver, error) {
+	primary, err := dbInstance.DB()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get database connection pool")
+	}
+
+	r := &resolver{
+		driver:  cfg.Driver,
+		primary: primary,
+		byConn:  map[gorm.ConnPool]*replica{},
+	}
+	for _, replicaConfig := range cfg.Replicas {
+		replicaCfg := replicaDatabaseConfig(cfg, replicaConfig)
+		conn, err := openConn(replicaCfg)
+		if err != nil {
+			r.Close()
+			return nil, errors.WithMessagef(err, "failed to open replica %s", replicaConfig.Name)
+		}
+		configurePool(conn, replicaCfg)
+		replica := &replica{name: replicaConfig.Name, conn: conn}
+		replica.healthy.Store(true)
+		r.replicas = append(r.replicas, replica)
+		r.byConn[conn] = replica
+	}
+	r.check(context.Background())
+
+	plugin := dbresolver.Register(dbresolver.Config{
+		Replicas: r.dialectors(r.replicas),
+		Policy:   r.policy(cfg.Policy),
+	})
+	for _, table := range cfg.Tables {
+		var replicas []*replica
+		for _, name := range table.Replicas {
+			replicas = append(replicas, r.replica(name))
+		}
+		tables := make([]any, 0, len(table.Names))
+		for _, name := range table.Names {
+			tables = append(tables, name)
+		}
+		plugin.Register(dbresolver.Config{
+			Replicas: r.dialectors(replicas),
+			Policy:   r.policy(valueOrDefault(table.Policy, cfg.Policy)),
+		}, tables...)
+	}
+
+	if err := dbInstance.Use(plugin); err != nil {
+		r.Close()
+		return nil, errors.WithMessage(err, "failed to register dbresolver")
+	}
+	if err := r.registerCallbacks(dbInstance); err != nil {
+		r.Close()
+		return nil, err
+	}
+
+	if len(r.replicas) > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		r.cancel = cancel
+		r.done = make(chan struct{})
+		go r.watch(ctx, cfg.HealthCheckInterval)
+	}
+	return r, nil
+}
+
+// dialectors 副本后追加主库，全部副本不可用时由 healthPolicy 选择主库
+func (r *resolver) dialectors(replicas []*replica) []gorm.Dialector {
+	if len(replicas) == 0 {
+		return nil
+	}
+	dialectors := make([]gorm.Dialector, 0, len(replicas)+1)
+	for _, replica := range replicas {
+		dialectors = append(dialectors, connDialector(r.driver, replica.conn))
+	}
+	return append(dialectors, connDialector(r.driver, r.primary))
+}
+
+func (r *resolver) replica(name string) *replica {
+	for _, replica := range r.replicas {
+		if replica.name == name {
+			return replica
+		}
+	}
+	return nil
+}
+
+func (r *resolver) policy(name string) dbresolver.Policy {
+	var policy dbresolver.Policy
+	switch name {
+	case "round_robin":
+		policy = dbresolver.StrictRoundRobinPolicy()
+	case "least_conn":
+		policy = dbresolver.PolicyFunc(leastConnPolicy)
+	default:
+		policy = dbresolver.RandomPolicy{}
+	}
+	return healthPolicy{resolver: r, policy: policy}
+}
+
+// healthPolicy 只在健康的副本中选择，全部副本不可用时使用主库
+type healthPolicy struct {
+	resolver *resolver
+	policy   dbresolver.Policy
+}
+
+func (p healthPolicy) Resolve(connPools []gorm.ConnPool) gorm.ConnPool {
+	healthy := make([]gorm.ConnPool, 0, len(connPools))
+	for _, connPool := range connPools {
+		if replica, ok := p.resolver.byConn[connPool]; ok && replica.healthy.Load() {
+			healthy = append(healthy, connPool)
+		}
+	}
+	if len(healthy) == 0 {
+		return p.resolver.primary
+	}
+	return p.policy.Resolve(healthy)
+}
+
+// leastConnPolicy 选择使用中连接数最少的副本
+func leastConnPolicy(connPools []gorm.ConnPool) gorm.ConnPool {
+	selected, minInUse := connPools[0], -1
+	for _, connPool := range connPools {
+		conn, ok := connPool.(*sql.DB)
+		if !ok {
+			continue
+		}
+		if inUse := conn.Stats().InUse; minInUse < 0 || inUse < minInUse {
+			selected, minInUse = connPool, inUse
+		}
+	}
+	return selected
+}
+
+// registerCallbacks 在 dbresolver 选择数据源之后记录查询使用的数据源
+func (r *resolver) registerCallbacks(dbInstance *gorm.DB) error {
+	const name = "tele:resolver_metrics"
+	callback := dbInstance.Callback()
+	for _, err := range []error{
+		callback.Create().After("gorm:db_resolver").Register(name, r.record("create")),
+		callback.Query().After("gorm:db_resolver").Register(name, r.record("query")),
+		callback.Update().After("gorm:db_resolver").Register(name, r.record("update")),
+		callback.Delete().After("gorm:db_resolver").Register(name, r.record("delete")),
+		callback.Row().After("gorm:db_resolver").Register(name, r.record("row")),
+		callback.Raw().After("gorm:db_resolver").Register(name, r.record("raw")),
+	} {
+		if err != nil {
+			return errors.WithMessage(err, "failed to register resolver callbacks")
+		}
+	}
+	return nil
+}
+
+func (r *resolver) record(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		ResolverQueryCounter.WithLabelValues(r.source(tx.Statement.ConnPool), operation).Inc()
+	}
+}
+
+// source 连接池对应的数据源名称
+func (r *resolver) source(connPool gorm.ConnPool) string {
+	if prepared, ok := connPool.(*gorm.PreparedStmtDB); ok {
+		connPool = prepared.ConnPool
+	}
+	if replica, ok := r.byConn[connPool]; ok {
+		return replica.name
+	}
+	return primarySource
+}
+
+func (r *resolver) watch(ctx context.Context, interval time.Duration) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.check(ctx)
+		}
+	}
+}
+
+// check 并行检查全部副本，状态变化时记录日志
+func (r *resolver) check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, replica := range r.replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.checkReplica(ctx, replica)
+		}()
+	}
+	wg.Wait()
+}
+
+func (r *resolver) checkReplica(ctx context.Context, replica *replica) {
+	pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+	err := replica.conn.PingContext(pingCtx)
+	cancel()
+
+	healthy := err == nil
+	if healthy {
+		ReplicaHealthy.WithLabelValues(replica.name).Set(1)
+	} else {
+		ReplicaHealthy.WithLabelValues(replica.name).Set(0)
+	}
+	if replica.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		slog.Info("[DB] Replica restored", "Replica", replica.name)
+	} else {
+		slog.Warn("[DB] Replica ejected", "Replica", replica.name, "Error", err)
+	}
+}
+
+// Close 停止健康检查并关闭副本连接池
+func (r *resolver) Close() error {
+	if r.cancel != nil {
+		r.cancel()
+		<-r.done
+	}
+
+	var closeErr error
+	for _, replica := range r.replicas {
+		if err := replica.conn.Close(); err != nil && closeErr == nil {
+			closeErr = errors.WithMessagef(err, "failed to close replica %s", replica.name)
+		}
+	}
+	return closeErr
+}
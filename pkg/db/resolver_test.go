@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"telecommunications_repair_hub/config"
+	"telecommunications_repair_hub/models"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newReplicatedDB 主库和副本使用不同的 sqlite 文件，副本中的数据不同，用于区分查询使用的数据源
+func newReplicatedDB(t *testing.T, tables []config.TableResolverConfig) *DB {
+	t.Helper()
+	ctx := context.Background()
+	dir := t.TempDir()
+	replicaPath := filepath.Join(dir, "replica.db")
+
+	replica, err := New(&config.Config{Database: &config.DatabaseConfig{Driver: DriverSQLite, Path: replicaPath}})
+	require.NoError(t, err)
+	_, err = replica.MigrateUp(ctx, MigrateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, replica.Create(&models.User{Username: "副本", Phone: "13800000002", Role: models.UserRoleEndUser}).Error)
+	require.NoError(t, replica.Close())
+
+	database, err := New(&config.Config{Database: &config.DatabaseConfig{
+		Driver:              DriverSQLite,
+		Path:                filepath.Join(dir, "primary.db"),
+		Policy:              "round_robin",
+		HealthCheckInterval: time.Hour,
+		Replicas:            []config.ReplicaConfig{{Name: "replica1", Path: replicaPath}},
+		Tables:              tables,
+	}})
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	_, err = database.MigrateUp(ctx, MigrateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, database.Create(&models.User{Username: "主库", Phone: "13800000001", Role: models.UserRoleEndUser}).Error)
+	return database
+}
+
+func TestResolver_ReadsFromReplica(t *testing.T) {
+	database := newReplicatedDB(t, nil)
+	reads := testutil.ToFloat64(ResolverQueryCounter.WithLabelValues("replica1", "query"))
+
+	var user models.User
+	require.NoError(t, database.First(&user).Error)
+	assert.Equal(t, "副本", user.Username)
+	assert.Equal(t, reads+1, testutil.ToFloat64(ResolverQueryCounter.WithLabelValues("replica1", "query")))
+
+	// 事务中的查询使用主库
+	require.NoError(t, database.Transaction(func(tx *gorm.DB) error {
+		return tx.First(&user).Error
+	}))
+	assert.Equal(t, "主库", user.Username)
+}
+
+func TestResolver_TableReadsFromPrimary(t *testing.T) {
+	database := newReplicatedDB(t, []config.TableResolverConfig{{Names: []string{"tele_user"}}})
+
+	var user models.User
+	require.NoError(t, database.First(&user).Error)
+	assert.Equal(t, "主库", user.Username)
+}
+
+func TestResolver_EjectsUnhealthyReplica(t *testing.T) {
+	database := newReplicatedDB(t, nil)
+	replica := database.resolver.replicas[0]
+
+	require.NoError(t, replica.conn.Close())
+	database.resolver.check(context.Background())
+	assert.False(t, replica.healthy.Load())
+	assert.Equal(t, float64(0), testutil.ToFloat64(ReplicaHealthy.WithLabelValues("replica1")))
+
+	var user models.User
+	require.NoError(t, database.First(&user).Error)
+	assert.Equal(t, "主库", user.Username)
+}
@@ -1,45 +1,221 @@
 package pkg
 
-import "errors"
-
-type TeleCommunicationErrorType error
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
 
+// TeleCommunicationError 业务错误
+//
+// Code 为稳定的业务码，一经发布不再修改，和 HTTP 状态码相互独立；
+// HTTPStatus 为返回给客户端的 HTTP 状态码；I18nKey 供前端做多语言映射；
+// Messages 为各语言的默认提示，key 为语言标签（zh、en）。
 type TeleCommunicationError struct {
-	ErrorType TeleCommunicationErrorType
-	ErrorCode int
+	Code       int
+	HTTPStatus int
+	I18nKey    string
+	Messages   map[string]string
+
+	cause error
 }
 
-var (
-	TeleCommunicationDict = map[TeleCommunicationErrorType]TeleCommunicationError{
-		ErrNoPermission: {
-			ErrorType: ErrNoPermission,
-			ErrorCode: 401,
-		},
-		ErrParamError: {
-			ErrorType: ErrParamError,
-			ErrorCode: 400,
-		},
-		ErrUserNotFound: {
-			ErrorType: ErrUserNotFound,
-			ErrorCode: 404,
-		},
+// 默认语言
+const DefaultLanguage = "zh"
+
+var registry = map[int]*TeleCommunicationError{}
+
+// Register 注册业务错误，业务码重复时 panic
+func Register(code int, httpStatus int, i18nKey string, messages map[string]string) *TeleCommunicationError {
+	if _, ok := registry[code]; ok {
+		panic(fmt.Sprintf("duplicate error code %d", code))
 	}
-)
+	if _, ok := messages[DefaultLanguage]; !ok {
+		panic(fmt.Sprintf("error code %d has no %s message", code, DefaultLanguage))
+	}
+
+	e := &TeleCommunicationError{
+		Code:       code,
+		HTTPStatus: httpStatus,
+		I18nKey:    i18nKey,
+		Messages:   messages,
+	}
+	registry[code] = e
+	return e
+}
+
+// Lookup 查找错误链中的业务错误
+func Lookup(err error) (*TeleCommunicationError, bool) {
+	var e *TeleCommunicationError
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// Errors 已注册的全部业务错误，按业务码排序
+func Errors() []*TeleCommunicationError {
+	errs := make([]*TeleCommunicationError, 0, len(registry))
+	for _, e := range registry {
+		errs = append(errs, e)
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Code < errs[j].Code })
+	return errs
+}
+
+// GetTeleCommunicationErrorCode 获取错误对应的业务码，未注册的错误返回 ErrInternal 的业务码
+func GetTeleCommunicationErrorCode(err error) int {
+	if e, ok := Lookup(err); ok {
+		return e.Code
+	}
+	return ErrInternal.Code
+}
+
+// Message 默认语言的提示
+func (e *TeleCommunicationError) Message() string {
+	return e.Messages[DefaultLanguage]
+}
+
+// Localize 指定语言的提示，没有对应语言时返回默认语言的提示
+func (e *TeleCommunicationError) Localize(language string) string {
+	if message, ok := e.Messages[language]; ok {
+		return message
+	}
+	return e.Message()
+}
+
+func (e *TeleCommunicationError) Error() string {
+	if e.cause != nil {
+		return e.Message() + ": " + e.cause.Error()
+	}
+	return e.Message()
+}
+
+func (e *TeleCommunicationError) Unwrap() error {
+	return e.cause
+}
+
+// Is 业务码相同即视为同一错误，因此 Wrap 之后的错误仍满足 errors.Is(err, ErrXxx)
+func (e *TeleCommunicationError) Is(target error) bool {
+	t, ok := target.(*TeleCommunicationError)
+	return ok && t.Code == e.Code
+}
+
+// Cause 被包装的原始错误
+func (e *TeleCommunicationError) Cause() error {
+	return e.cause
+}
+
+// Wrap 以当前业务错误包装 cause，返回新的错误，不修改已注册的错误
+func (e *TeleCommunicationError) Wrap(cause error) *TeleCommunicationError {
+	wrapped := *e
+	wrapped.cause = cause
+	return &wrapped
+}
 
-func GetTeleCommunicationErrorCode(errorType TeleCommunicationErrorType) int {
-	return TeleCommunicationDict[errorType].ErrorCode
+// Wrapf 以当前业务错误包装格式化的错误详情
+func (e *TeleCommunicationError) Wrapf(format string, args ...any) *TeleCommunicationError {
+	return e.Wrap(fmt.Errorf(format, args...))
 }
 
+// 通用错误 1xxxx
 var (
-	// 没有权限
-	ErrNoPermission TeleCommunicationErrorType = errors.New("没有权限")
+	// 服务器内部错误，未注册的错误统一按此错误返回
+	ErrInternal = Register(10000, http.StatusInternalServerError, "error.internal", map[string]string{
+		"zh": "服务器内部错误",
+		"en": "internal server error",
+	})
 
 	// 参数错误
-	ErrParamError TeleCommunicationErrorType = errors.New("参数错误")
+	ErrParamError = Register(10001, http.StatusBadRequest, "error.param", map[string]string{
+		"zh": "参数错误",
+		"en": "invalid parameter",
+	})
 
-	// 用户不存在
-	ErrUserNotFound TeleCommunicationErrorType = errors.New("用户不存在")
+	// 接口不存在
+	ErrNotFound = Register(10002, http.StatusNotFound, "error.not_found", map[string]string{
+		"zh": "接口不存在",
+		"en": "not found",
+	})
+
+	// 请求方法不允许
+	ErrMethodNotAllowed = Register(10003, http.StatusMethodNotAllowed, "error.method_not_allowed", map[string]string{
+		"zh": "请求方法不允许",
+		"en": "method not allowed",
+	})
 
+	// 请求体过大
+	ErrRequestTooLarge = Register(10004, http.StatusRequestEntityTooLarge, "error.request_too_large", map[string]string{
+		"zh": "请求体过大",
+		"en": "request entity too large",
+	})
+
+	// 不支持的接口版本
+	ErrUnsupportedAPIVersion = Register(10005, http.StatusBadRequest, "error.unsupported_api_version", map[string]string{
+		"zh": "不支持的接口版本",
+		"en": "unsupported api version",
+	})
+)
+
+// 认证和权限 11xxx
+var (
 	// 无效的token
-	ErrInvalidToken TeleCommunicationErrorType = errors.New("无效的token")
+	ErrInvalidToken = Register(11001, http.StatusUnauthorized, "error.invalid_token", map[string]string{
+		"zh": "无效的token",
+		"en": "invalid token",
+	})
+
+	// 没有权限
+	ErrNoPermission = Register(11002, http.StatusForbidden, "error.no_permission", map[string]string{
+		"zh": "没有权限",
+		"en": "permission denied",
+	})
 )
+
+// 用户 20xxx
+var (
+	// 用户不存在
+	ErrUserNotFound = Register(20001, http.StatusNotFound, "error.user.not_found", map[string]string{
+		"zh": "用户不存在",
+		"en": "user not found",
+	})
+)
+
+// 工单 30xxx
+var (
+	// 工单不存在
+	ErrTicketNotFound = Register(30001, http.StatusNotFound, "error.ticket.not_found", map[string]string{
+		"zh": "工单不存在",
+		"en": "ticket not found",
+	})
+
+	// 工单状态流转非法
+	ErrIllegalTicketTransition = Register(30002, http.StatusConflict, "error.ticket.illegal_transition", map[string]string{
+		"zh": "工单状态流转非法",
+		"en": "illegal ticket status transition",
+	})
+)
+
+// 区域 40xxx
+var (
+	// 区域不存在
+	ErrRegionNotFound = Register(40001, http.StatusNotFound, "error.region.not_found", map[string]string{
+		"zh": "区域不存在",
+		"en": "region not found",
+	})
+)
+
+// TicketTransitionError 工单状态流转非法，errors.Is(err, ErrIllegalTicketTransition) 为 true
+type TicketTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *TicketTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s", ErrIllegalTicketTransition.Error(), e.From, e.To)
+}
+
+func (e *TicketTransitionError) Unwrap() error {
+	return ErrIllegalTicketTransition
+}
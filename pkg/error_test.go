@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeleCommunicationError_Wrap(t *testing.T) {
+	cause := errors.New("name is empty")
+	err := fmt.Errorf("create user: %w", ErrParamError.Wrap(cause))
+
+	assert.ErrorIs(t, err, ErrParamError)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrUserNotFound)
+
+	e, ok := Lookup(err)
+	assert.True(t, ok)
+	assert.Equal(t, 10001, e.Code)
+	assert.Equal(t, http.StatusBadRequest, e.HTTPStatus)
+	assert.Equal(t, cause, e.Cause())
+	assert.Equal(t, "参数错误: name is empty", e.Error())
+
+	// Wrap 不修改已注册的错误
+	assert.Nil(t, ErrParamError.Cause())
+}
+
+func TestTeleCommunicationError_Localize(t *testing.T) {
+	assert.Equal(t, "没有权限", ErrNoPermission.Localize("zh"))
+	assert.Equal(t, "permission denied", ErrNoPermission.Localize("en"))
+	assert.Equal(t, "没有权限", ErrNoPermission.Localize("fr"))
+}
+
+func TestGetTeleCommunicationErrorCode(t *testing.T) {
+	assert.Equal(t, ErrInvalidToken.Code, GetTeleCommunicationErrorCode(ErrInvalidToken))
+	assert.Equal(t, ErrIllegalTicketTransition.Code, GetTeleCommunicationErrorCode(&TicketTransitionError{From: "a", To: "b"}))
+	assert.Equal(t, ErrInternal.Code, GetTeleCommunicationErrorCode(errors.New("unknown")))
+}
+
+func TestRegister_UniqueCode(t *testing.T) {
+	codes := map[int]bool{}
+	for _, e := range Errors() {
+		assert.False(t, codes[e.Code], "duplicate code %d", e.Code)
+		codes[e.Code] = true
+		assert.NotEmpty(t, e.I18nKey)
+		assert.NotEmpty(t, e.Localize("en"))
+	}
+
+	assert.Panics(t, func() {
+		Register(ErrInternal.Code, http.StatusInternalServerError, "error.duplicate", map[string]string{"zh": "重复"})
+	})
+}
@@ -0,0 +1,24 @@
+//go:build unix
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+)
+
+// DiskSpaceCheck 检查 path 所在磁盘的可用空间不低于 minFreeBytes
+func DiskSpaceCheck(path string, minFreeBytes uint64) Check {
+	return func(ctx context.Context) error {
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(path, &stat); err != nil {
+			return err
+		}
+		free := stat.Bavail * uint64(stat.Bsize)
+		if free < minFreeBytes {
+			return fmt.Errorf("disk free space %dMB is below %dMB at %s", free>>20, minFreeBytes>>20, path)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package health
+
+import "context"
+
+// DiskSpaceCheck 非 unix 平台不检查磁盘空间
+func DiskSpaceCheck(path string, minFreeBytes uint64) Check {
+	return func(ctx context.Context) error {
+		return nil
+	}
+}
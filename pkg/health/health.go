@@ -0,0 +1,155 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status 检查结果
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Kind 检查类型
+type Kind string
+
+const (
+	// 存活检查失败时进程应被重启，只应包含进程自身的检查，不应包含外部依赖
+	KindLiveness Kind = "liveness"
+	// 就绪检查失败时摘除流量，包含数据库等外部依赖
+	KindReadiness Kind = "readiness"
+)
+
+// 单个检查的默认超时时间
+const DefaultTimeout = 2 * time.Second
+
+// Check 健康检查，返回 nil 表示健康
+type Check func(ctx context.Context) error
+
+// Result 单个检查的结果
+type Result struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	// 检查耗时，单位毫秒
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report 全部检查的结果，任一检查失败时 Status 为 down
+type Report struct {
+	Status Status    `json:"status"`
+	Checks []*Result `json:"checks"`
+}
+
+type registeredCheck struct {
+	name    string
+	kind    Kind
+	check   Check
+	timeout time.Duration
+}
+
+// Registry 健康检查注册表
+type Registry struct {
+	mu     sync.RWMutex
+	checks map[string]*registeredCheck
+}
+
+// Default 默认注册表，/livez 和 /readyz 使用该注册表，各子系统在初始化时注册自己的检查
+var Default = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{checks: map[string]*registeredCheck{}}
+}
+
+// Register 注册检查，同名检查会被覆盖，timeout 为 0 时使用 DefaultTimeout
+func (r *Registry) Register(name string, kind Kind, timeout time.Duration, check Check) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = &registeredCheck{name: name, kind: kind, check: check, timeout: timeout}
+}
+
+// Unregister 移除检查
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.checks, name)
+}
+
+// Run 并发执行指定类型的全部检查，每个检查有独立的超时时间，结果按名称排序
+func (r *Registry) Run(ctx context.Context, kind Kind) *Report {
+	r.mu.RLock()
+	checks := make([]*registeredCheck, 0, len(r.checks))
+	for _, c := range r.checks {
+		if c.kind == kind {
+			checks = append(checks, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	report := &Report{Status: StatusUp, Checks: make([]*Result, len(checks))}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = run(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(report.Checks, func(i, j int) bool { return report.Checks[i].Name < report.Checks[j].Name })
+	for _, result := range report.Checks {
+		if result.Status == StatusDown {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func run(ctx context.Context, c *registeredCheck) (result *Result) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	result = &Result{Name: c.name, Status: StatusUp}
+	defer func() {
+		result.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	}()
+
+	// 检查不响应 ctx 时也按超时返回
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- c.check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			result.Status = StatusDown
+			result.Error = err.Error()
+		}
+	case <-ctx.Done():
+		result.Status = StatusDown
+		result.Error = ctx.Err().Error()
+	}
+	return result
+}
+
+// Register 在默认注册表中注册检查
+func Register(name string, kind Kind, timeout time.Duration, check Check) {
+	Default.Register(name, kind, timeout, check)
+}
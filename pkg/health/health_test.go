@@ -0,0 +1,49 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Run(t *testing.T) {
+	r := NewRegistry()
+	r.Register("database", KindReadiness, 0, func(ctx context.Context) error { return nil })
+	r.Register("cache", KindReadiness, 0, func(ctx context.Context) error { return errors.New("connection refused") })
+	r.Register("slow", KindReadiness, 10*time.Millisecond, func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+	r.Register("panic", KindReadiness, 0, func(ctx context.Context) error { panic("boom") })
+	r.Register("goroutines", KindLiveness, 0, func(ctx context.Context) error { return nil })
+
+	report := r.Run(context.Background(), KindReadiness)
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Len(t, report.Checks, 4)
+	results := map[string]*Result{}
+	for _, result := range report.Checks {
+		results[result.Name] = result
+	}
+	assert.Equal(t, StatusUp, results["database"].Status)
+	assert.Equal(t, "connection refused", results["cache"].Error)
+	assert.Equal(t, context.DeadlineExceeded.Error(), results["slow"].Error)
+	assert.Less(t, results["slow"].LatencyMs, float64(500))
+	assert.Equal(t, "panic: boom", results["panic"].Error)
+
+	report = r.Run(context.Background(), KindLiveness)
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Len(t, report.Checks, 1)
+}
+
+func TestDiskSpaceCheck(t *testing.T) {
+	assert.NoError(t, DiskSpaceCheck(".", 1)(context.Background()))
+	assert.Error(t, DiskSpaceCheck(".", 1<<62)(context.Background()))
+}
+
+func TestGoroutineCheck(t *testing.T) {
+	assert.NoError(t, GoroutineCheck(1<<20)(context.Background()))
+	assert.Error(t, GoroutineCheck(0)(context.Background()))
+}
@@ -0,0 +1,17 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// GoroutineCheck 检查 goroutine 数量不超过 max，goroutine 泄漏或大量请求阻塞时失败
+func GoroutineCheck(max int) Check {
+	return func(ctx context.Context) error {
+		if n := runtime.NumGoroutine(); n > max {
+			return fmt.Errorf("goroutine count %d exceeds %d", n, max)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,175 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Component 由 Container 管理的子系统
+type Component struct {
+	Name string
+	// 依赖的组件，依赖的组件启动完成后才会启动当前组件
+	DependsOn []string
+	// Start 启动组件，不应阻塞，常驻任务应在后台运行并通过 Container.Fail 报告异常退出
+	Start func(ctx context.Context) error
+	// Stop 停止组件，可为空
+	Stop func(ctx context.Context) error
+}
+
+// Container 组件容器，按依赖顺序启动组件，按启动的逆序停止组件
+type Container struct {
+	mu         sync.Mutex
+	components []*Component
+	started    []*Component
+
+	failOnce sync.Once
+	failed   chan error
+}
+
+func NewContainer() *Container {
+	return &Container{failed: make(chan error, 1)}
+}
+
+// Register 注册组件，组件名重复时返回错误
+func (c *Container) Register(component Component) error {
+	if component.Name == "" {
+		return errors.New("component name is empty")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, registered := range c.components {
+		if registered.Name == component.Name {
+			return fmt.Errorf("duplicate component %s", component.Name)
+		}
+	}
+	c.components = append(c.components, &component)
+	return nil
+}
+
+// Start 按依赖顺序启动全部组件。
+// 依赖不存在、循环依赖或任一组件启动失败（包括 panic）时，停止已启动的组件并返回错误。
+func (c *Container) Start(ctx context.Context) error {
+	c.mu.Lock()
+	order, err := c.startOrder()
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, component := range order {
+		start := time.Now()
+		if err := call(ctx, component.Start); err != nil {
+			err = pkgerrors.WithMessagef(err, "failed to start component %s", component.Name)
+			if stopErr := c.Stop(ctx); stopErr != nil {
+				err = errors.Join(err, stopErr)
+			}
+			return err
+		}
+
+		c.mu.Lock()
+		c.started = append(c.started, component)
+		c.mu.Unlock()
+		slog.Info("[Container] Component started", "Name", component.Name, "Duration", time.Since(start))
+	}
+	return nil
+}
+
+// Stop 按启动的逆序停止已启动的组件，单个组件停止失败不影响其他组件，返回全部错误
+func (c *Container) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	started := c.started
+	c.started = nil
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		component := started[i]
+		if err := call(ctx, component.Stop); err != nil {
+			slog.Error("[Container] Component stop failed", "Name", component.Name, "Error", err)
+			errs = append(errs, pkgerrors.WithMessagef(err, "failed to stop component %s", component.Name))
+			continue
+		}
+		slog.Info("[Container] Component stopped", "Name", component.Name)
+	}
+	return errors.Join(errs...)
+}
+
+// Fail 报告组件在运行中异常退出，只保留第一个错误
+func (c *Container) Fail(name string, err error) {
+	c.failOnce.Do(func() {
+		c.failed <- pkgerrors.WithMessagef(err, "component %s failed", name)
+	})
+}
+
+// Failed 组件运行中异常退出时收到错误
+func (c *Container) Failed() <-chan error {
+	return c.failed
+}
+
+// startOrder 按依赖关系排序，同一层级保持注册顺序
+func (c *Container) startOrder() ([]*Component, error) {
+	byName := make(map[string]*Component, len(c.components))
+	for _, component := range c.components {
+		byName[component.Name] = component
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(c.components))
+	order := make([]*Component, 0, len(c.components))
+
+	var visit func(component *Component, path []string) error
+	visit = func(component *Component, path []string) error {
+		switch state[component.Name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("circular component dependency: %s", strings.Join(append(path, component.Name), " -> "))
+		}
+
+		state[component.Name] = visiting
+		for _, name := range component.DependsOn {
+			dependency, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("component %s depends on unknown component %s", component.Name, name)
+			}
+			if err := visit(dependency, append(path, component.Name)); err != nil {
+				return err
+			}
+		}
+		state[component.Name] = visited
+		order = append(order, component)
+		return nil
+	}
+
+	for _, component := range c.components {
+		if err := visit(component, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// call 调用组件的启动或停止函数，panic 转换为错误
+func call(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if fn == nil {
+		return nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
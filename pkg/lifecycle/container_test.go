@@ -0,0 +1,76 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordComponent(events *[]string, name string, dependsOn ...string) Component {
+	return Component{
+		Name:      name,
+		DependsOn: dependsOn,
+		Start: func(ctx context.Context) error {
+			*events = append(*events, "start "+name)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			*events = append(*events, "stop "+name)
+			return nil
+		},
+	}
+}
+
+func TestContainer_StartStopOrder(t *testing.T) {
+	events := []string{}
+	c := NewContainer()
+	assert.NoError(t, c.Register(recordComponent(&events, "http", "db", "logger")))
+	assert.NoError(t, c.Register(recordComponent(&events, "db", "config", "logger")))
+	assert.NoError(t, c.Register(recordComponent(&events, "logger", "config")))
+	assert.NoError(t, c.Register(recordComponent(&events, "config")))
+	assert.Error(t, c.Register(recordComponent(&events, "config")))
+
+	assert.NoError(t, c.Start(context.Background()))
+	assert.NoError(t, c.Stop(context.Background()))
+	assert.Equal(t, []string{
+		"start config", "start logger", "start db", "start http",
+		"stop http", "stop db", "stop logger", "stop config",
+	}, events)
+}
+
+func TestContainer_StartFailure(t *testing.T) {
+	events := []string{}
+	c := NewContainer()
+	assert.NoError(t, c.Register(recordComponent(&events, "config")))
+	assert.NoError(t, c.Register(Component{
+		Name:      "db",
+		DependsOn: []string{"config"},
+		Start:     func(ctx context.Context) error { panic("dial tcp: connection refused") },
+	}))
+	assert.NoError(t, c.Register(recordComponent(&events, "http", "db")))
+
+	err := c.Start(context.Background())
+	assert.EqualError(t, err, "failed to start component db: panic: dial tcp: connection refused")
+	// 已启动的组件被停止，依赖失败组件的组件不会启动
+	assert.Equal(t, []string{"start config", "stop config"}, events)
+}
+
+func TestContainer_InvalidDependencies(t *testing.T) {
+	c := NewContainer()
+	assert.NoError(t, c.Register(Component{Name: "a", DependsOn: []string{"b"}}))
+	assert.NoError(t, c.Register(Component{Name: "b", DependsOn: []string{"a"}}))
+	assert.EqualError(t, c.Start(context.Background()), "circular component dependency: a -> b -> a")
+
+	c = NewContainer()
+	assert.NoError(t, c.Register(Component{Name: "http", DependsOn: []string{"db"}}))
+	assert.EqualError(t, c.Start(context.Background()), "component http depends on unknown component db")
+}
+
+func TestContainer_Fail(t *testing.T) {
+	c := NewContainer()
+	c.Fail("worker", errors.New("exited"))
+	c.Fail("http", errors.New("ignored"))
+	assert.EqualError(t, <-c.Failed(), "component worker failed: exited")
+}
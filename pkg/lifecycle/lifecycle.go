@@ -0,0 +1,88 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Hook 关闭阶段执行的钩子，ctx 的截止时间为关闭超时时间
+type Hook func(ctx context.Context) error
+
+type namedHook struct {
+	name string
+	hook Hook
+}
+
+var (
+	ready        atomic.Bool
+	shuttingDown atomic.Bool
+
+	mu    sync.Mutex
+	hooks []namedHook
+)
+
+// Ready 服务是否可以接收流量，开始关闭后始终为 false
+func Ready() bool {
+	return ready.Load() && !shuttingDown.Load()
+}
+
+// SetReady 设置服务是否可以接收流量
+func SetReady(value bool) {
+	ready.Store(value)
+}
+
+// ShuttingDown 服务是否正在关闭
+func ShuttingDown() bool {
+	return shuttingDown.Load()
+}
+
+// BeginShutdown 标记服务开始关闭，readiness 随即变为 false，负载均衡摘除流量后再停止 HTTP 服务
+func BeginShutdown() {
+	shuttingDown.Store(true)
+}
+
+// OnShutdown 注册关闭钩子，钩子按注册的逆序执行，先注册的子系统（如数据库）最后关闭
+func OnShutdown(name string, hook Hook) {
+	mu.Lock()
+	defer mu.Unlock()
+	hooks = append(hooks, namedHook{name: name, hook: hook})
+}
+
+// Shutdown 标记服务开始关闭并按注册的逆序执行全部关闭钩子，
+// 单个钩子失败不影响后续钩子执行，返回全部钩子的错误
+func Shutdown(ctx context.Context) error {
+	BeginShutdown()
+
+	mu.Lock()
+	registered := hooks
+	hooks = nil
+	mu.Unlock()
+
+	var errs []error
+	for i := len(registered) - 1; i >= 0; i-- {
+		h := registered[i]
+		start := time.Now()
+		if err := h.hook(ctx); err != nil {
+			slog.Error("[Lifecycle] Shutdown hook failed", "Name", h.name, "Error", err)
+			errs = append(errs, pkgerrors.WithMessagef(err, "shutdown hook %s", h.name))
+			continue
+		}
+		slog.Info("[Lifecycle] Shutdown hook done", "Name", h.name, "Duration", time.Since(start))
+	}
+	return errors.Join(errs...)
+}
+
+// Reset 清空关闭钩子和服务状态，仅用于测试
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	hooks = nil
+	ready.Store(false)
+	shuttingDown.Store(false)
+}
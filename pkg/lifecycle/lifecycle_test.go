@@ -0,0 +1,41 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdown(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	SetReady(true)
+	assert.True(t, Ready())
+
+	order := []string{}
+	OnShutdown("database", func(ctx context.Context) error {
+		order = append(order, "database")
+		return nil
+	})
+	OnShutdown("cache", func(ctx context.Context) error {
+		order = append(order, "cache")
+		return errors.New("flush failed")
+	})
+	OnShutdown("metrics", func(ctx context.Context) error {
+		order = append(order, "metrics")
+		return nil
+	})
+
+	err := Shutdown(context.Background())
+	assert.ErrorContains(t, err, "shutdown hook cache: flush failed")
+	assert.Equal(t, []string{"metrics", "cache", "database"}, order)
+	assert.False(t, Ready())
+	assert.True(t, ShuttingDown())
+
+	// 钩子只执行一次
+	assert.NoError(t, Shutdown(context.Background()))
+	assert.Len(t, order, 3)
+}
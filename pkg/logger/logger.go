@@ -16,6 +16,9 @@ type Logger struct {
 	RotationSize  int
 	RotationCount int
 	RotationTime  string
+
+	rotater *lumberjack.Logger
+	level   slog.LevelVar
 }
 
 func NewLogger(level, output, rotation,
@@ -33,22 +36,23 @@ func NewLogger(level, output, rotation,
 }
 
 func (l *Logger) Init() {
+	l.level.Set(l.GetLevel())
 	dsetWriter := io.MultiWriter(os.Stdout)
 
 	if l.Output == "file" {
-		rotater := lumberjack.Logger{
+		l.rotater = &lumberjack.Logger{
 			Filename:   l.Output,
 			MaxSize:    l.RotationSize,
 			MaxAge:     l.RotationCount,
 			MaxBackups: l.RotationCount,
 			Compress:   true,
 		}
-		dsetWriter = io.MultiWriter(dsetWriter, &rotater)
+		dsetWriter = io.MultiWriter(dsetWriter, l.rotater)
 	}
 
-	slog.SetDefault(slog.New(slog.NewTextHandler(dsetWriter,
+	slog.SetDefault(slog.New(contextHandler{Handler: slog.NewTextHandler(dsetWriter,
 		&slog.HandlerOptions{
-			Level: l.GetLevel(),
+			Level: &l.level,
 			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 				if t, ok := a.Value.Any().(time.Time); ok {
 					a.Value = slog.StringValue(t.Format(time.DateTime))
@@ -61,8 +65,22 @@ func (l *Logger) Init() {
 
 				return a
 			},
-		})))
+		})}))
+
+}
+
+// Close 关闭日志文件，确保退出前日志全部写入
+func (l *Logger) Close() error {
+	if l.rotater == nil {
+		return nil
+	}
+	return l.rotater.Close()
+}
 
+// SetLevel 修改日志级别，Init 之后调用立即生效
+func (l *Logger) SetLevel(level string) {
+	l.Level = level
+	l.level.Set(l.GetLevel())
 }
 
 func (l *Logger) GetLevel() slog.Level {
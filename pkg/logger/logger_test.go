@@ -1,7 +1,10 @@
 package logger
 
 import (
+	"bytes"
+	"context"
 	"log/slog"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,3 +21,24 @@ func TestLogger_GetLevel(t *testing.T) {
 	t.Log("level", level)
 	assert.Equal(t, slog.LevelInfo, level)
 }
+
+func TestLogger_SetLevel(t *testing.T) {
+	logger := NewLogger("info", "stdout", "1h", "1h", 1024, 3)
+	logger.Init()
+	assert.False(t, slog.Default().Enabled(nil, slog.LevelDebug))
+
+	logger.SetLevel("debug")
+	assert.True(t, slog.Default().Enabled(nil, slog.LevelDebug))
+}
+
+func TestContextHandler_AddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(contextHandler{Handler: slog.NewTextHandler(&buf, nil)})
+
+	logger.InfoContext(WithRequestID(context.Background(), "req-1"), "with request")
+	logger.InfoContext(context.Background(), "without request")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Contains(t, lines[0], "RequestID=req-1")
+	assert.NotContains(t, lines[1], "RequestID")
+}
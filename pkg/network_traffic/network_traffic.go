@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -31,6 +32,28 @@ const (
 	TrafficLimitUnitGB TrafficLimitUnit = 1024 * 1024 * 1024
 )
 
+// Limits 流量限制
+type Limits struct {
+	Limit TrafficLimitUnit
+	Burst TrafficLimitUnit
+}
+
+var defaultLimits atomic.Pointer[Limits]
+
+func init() {
+	defaultLimits.Store(&Limits{Limit: 10 * TrafficLimitUnitMB, Burst: 10 * TrafficLimitUnitMB})
+}
+
+// DefaultLimits 默认流量限制，新建的限制器使用，修改后对已创建的限制器不生效
+func DefaultLimits() Limits {
+	return *defaultLimits.Load()
+}
+
+// SetDefaultLimits 修改默认流量限制，支持运行时修改
+func SetDefaultLimits(limits Limits) {
+	defaultLimits.Store(&limits)
+}
+
 // NewNetworkTraffic 创建一个网络流量限制器
 func NewNetworkTraffic(limit, burst TrafficLimitUnit, src io.ReadSeekCloser) *NetworkTraffic {
 	limiter := rate.NewLimiter(rate.Limit(limit), int(burst))
@@ -2,15 +2,77 @@ package response
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
+// Mode 错误响应的 HTTP 状态码模式
+type Mode string
+
+const (
+	// 兼容模式：始终返回 HTTP 200，错误码只体现在 status 字段
+	ModeLegacy Mode = "legacy"
+	// 状态码模式：错误响应返回对应的 HTTP 状态码
+	ModeStatus Mode = "status"
+)
+
+// Format 错误响应的格式
+type Format string
+
+const (
+	// 统一响应结构 {status, message, data}
+	FormatEnvelope Format = "envelope"
+	// RFC 7807 application/problem+json
+	FormatProblem Format = "problem"
+)
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// echo.Context 中保存响应配置的 key
+const optionsKey = "tele_response_options"
+
+// Options 响应配置，按 Server 通过 Middleware 注入到请求上下文
+type Options struct {
+	Mode   Mode
+	Format Format
+}
+
+// DefaultOptions 未通过 Middleware 注入配置时使用的默认配置
+var DefaultOptions = Options{
+	Mode:   ModeStatus,
+	Format: FormatEnvelope,
+}
+
+// Middleware 为请求注入响应配置
+func Middleware(options Options) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(optionsKey, options)
+			return next(c)
+		}
+	}
+}
+
 type Response struct {
 	echo.Context `json:"-"`
 	Status       int    `json:"status"`
 	Message      string `json:"message"`
 	Data         any    `json:"data"`
+
+	httpStatus int
+}
+
+// Problem RFC 7807 错误响应，code 为扩展字段，对应业务码
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     int    `json:"code"`
+	// 扩展字段，通过 SetData 设置的结构化错误详情，如参数校验失败的字段列表
+	Errors any `json:"errors,omitempty"`
 }
 
 func NewResponse(ctx echo.Context) *Response {
@@ -27,6 +89,12 @@ func (r *Response) SetStatus(status int) *Response {
 	return r
 }
 
+// SetHTTPStatus 设置错误响应的 HTTP 状态码，兼容模式下不生效
+func (r *Response) SetHTTPStatus(status int) *Response {
+	r.httpStatus = status
+	return r
+}
+
 func (r *Response) SetMessage(message string) *Response {
 	r.Message = message
 	return r
@@ -55,6 +123,58 @@ func (r *Response) Error(data error) error {
 	if r.Status == 0 {
 		r.Status = http.StatusInternalServerError
 	}
-	r.Data = data.Error()
-	return r.Context.JSON(http.StatusOK, r)
+	// 未通过 SetData 设置 data 时，data 为错误详情
+	if r.Data == nil {
+		r.Data = data.Error()
+	}
+
+	options := r.options()
+	if options.Format == FormatProblem || r.acceptProblem() {
+		return r.problem(r.errorHTTPStatus(ModeStatus), data.Error())
+	}
+	return r.Context.JSON(r.errorHTTPStatus(options.Mode), r)
+}
+
+func (r *Response) options() Options {
+	if options, ok := r.Context.Get(optionsKey).(Options); ok {
+		return options
+	}
+	return DefaultOptions
+}
+
+// errorHTTPStatus 错误响应的 HTTP 状态码：兼容模式始终为 200；
+// 状态码模式优先使用 SetHTTPStatus 设置的状态码，其次是合法 HTTP 状态码范围内的 Status，否则为 500
+func (r *Response) errorHTTPStatus(mode Mode) int {
+	if mode == ModeLegacy {
+		return http.StatusOK
+	}
+	if r.httpStatus != 0 {
+		return r.httpStatus
+	}
+	if r.Status >= http.StatusBadRequest && r.Status < 600 {
+		return r.Status
+	}
+	return http.StatusInternalServerError
+}
+
+// acceptProblem 请求方是否通过 Accept 请求头要求 problem+json 格式
+func (r *Response) acceptProblem() bool {
+	return strings.Contains(r.Request().Header.Get(echo.HeaderAccept), MIMEApplicationProblemJSON)
+}
+
+func (r *Response) problem(httpStatus int, detail string) error {
+	problem := &Problem{
+		Type:     "about:blank",
+		Title:    r.Message,
+		Status:   httpStatus,
+		Detail:   detail,
+		Instance: r.Request().URL.Path,
+		Code:     r.Status,
+	}
+	if _, ok := r.Data.(string); !ok {
+		problem.Errors = r.Data
+	}
+	r.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	r.Response().WriteHeader(httpStatus)
+	return r.Echo().JSONSerializer.Serialize(r.Context, problem, "")
 }
@@ -0,0 +1,79 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContext(options *Options, accept string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/tickets/1", nil)
+	if accept != "" {
+		req.Header.Set(echo.HeaderAccept, accept)
+	}
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	if options != nil {
+		ctx.Set(optionsKey, *options)
+	}
+	return ctx, rec
+}
+
+func TestResponse_ErrorModes(t *testing.T) {
+	ctx, rec := newContext(&Options{Mode: ModeLegacy, Format: FormatEnvelope}, "")
+	assert.NoError(t, NewResponse(ctx).SetHTTPStatus(http.StatusNotFound).SetStatus(30001).Error(errors.New("not found")))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	ctx, rec = newContext(&Options{Mode: ModeStatus, Format: FormatEnvelope}, "")
+	assert.NoError(t, NewResponse(ctx).SetHTTPStatus(http.StatusNotFound).SetStatus(30001).Error(errors.New("not found")))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	body := map[string]any{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, float64(30001), body["status"])
+	assert.Equal(t, "not found", body["data"])
+
+	// 未设置 HTTP 状态码时使用合法范围内的 Status，否则为 500
+	ctx, rec = newContext(nil, "")
+	assert.NoError(t, NewResponse(ctx).SetStatus(http.StatusBadRequest).Error(errors.New("bad")))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	ctx, rec = newContext(nil, "")
+	assert.NoError(t, NewResponse(ctx).SetStatus(10001).Error(errors.New("bad")))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestResponse_Problem(t *testing.T) {
+	for _, ctxRec := range []func() (echo.Context, *httptest.ResponseRecorder){
+		func() (echo.Context, *httptest.ResponseRecorder) {
+			return newContext(&Options{Mode: ModeLegacy, Format: FormatProblem}, "")
+		},
+		func() (echo.Context, *httptest.ResponseRecorder) {
+			return newContext(nil, MIMEApplicationProblemJSON)
+		},
+	} {
+		ctx, rec := ctxRec()
+		err := NewResponse(ctx).
+			SetHTTPStatus(http.StatusConflict).
+			SetStatus(30002).
+			SetMessage("工单状态流转非法").
+			Error(errors.New("submitted -> resolved"))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+		problem := &Problem{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), problem))
+		assert.Equal(t, "工单状态流转非法", problem.Title)
+		assert.Equal(t, "submitted -> resolved", problem.Detail)
+		assert.Equal(t, http.StatusConflict, problem.Status)
+		assert.Equal(t, 30002, problem.Code)
+		assert.Equal(t, "/tickets/1", problem.Instance)
+	}
+}
@@ -0,0 +1,160 @@
+package validation
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Carrier 手机号码所属运营商
+type Carrier string
+
+const (
+	CarrierUnknown       Carrier = ""
+	CarrierChinaMobile   Carrier = "china_mobile"
+	CarrierChinaUnicom   Carrier = "china_unicom"
+	CarrierChinaTelecom  Carrier = "china_telecom"
+	CarrierChinaBroadnet Carrier = "china_broadnet"
+)
+
+var mobilePattern = regexp.MustCompile(`^1[3-9]\d{9}$`)
+
+// 号段，4 位号段优先于 3 位号段匹配
+var carrierPrefixes = map[string]Carrier{
+	// 中国移动
+	"134": CarrierChinaMobile, "135": CarrierChinaMobile, "136": CarrierChinaMobile, "137": CarrierChinaMobile,
+	"138": CarrierChinaMobile, "139": CarrierChinaMobile, "147": CarrierChinaMobile, "148": CarrierChinaMobile,
+	"150": CarrierChinaMobile, "151": CarrierChinaMobile, "152": CarrierChinaMobile, "157": CarrierChinaMobile,
+	"158": CarrierChinaMobile, "159": CarrierChinaMobile, "172": CarrierChinaMobile, "178": CarrierChinaMobile,
+	"182": CarrierChinaMobile, "183": CarrierChinaMobile, "184": CarrierChinaMobile, "187": CarrierChinaMobile,
+	"188": CarrierChinaMobile, "195": CarrierChinaMobile, "197": CarrierChinaMobile, "198": CarrierChinaMobile,
+	"1703": CarrierChinaMobile, "1705": CarrierChinaMobile, "1706": CarrierChinaMobile,
+	// 中国联通
+	"130": CarrierChinaUnicom, "131": CarrierChinaUnicom, "132": CarrierChinaUnicom, "145": CarrierChinaUnicom,
+	"146": CarrierChinaUnicom, "155": CarrierChinaUnicom, "156": CarrierChinaUnicom, "166": CarrierChinaUnicom,
+	"167": CarrierChinaUnicom, "171": CarrierChinaUnicom, "175": CarrierChinaUnicom, "176": CarrierChinaUnicom,
+	"185": CarrierChinaUnicom, "186": CarrierChinaUnicom, "196": CarrierChinaUnicom,
+	"1704": CarrierChinaUnicom, "1707": CarrierChinaUnicom, "1708": CarrierChinaUnicom, "1709": CarrierChinaUnicom,
+	// 中国电信
+	"133": CarrierChinaTelecom, "149": CarrierChinaTelecom, "153": CarrierChinaTelecom, "173": CarrierChinaTelecom,
+	"177": CarrierChinaTelecom, "180": CarrierChinaTelecom, "181": CarrierChinaTelecom, "189": CarrierChinaTelecom,
+	"190": CarrierChinaTelecom, "191": CarrierChinaTelecom, "193": CarrierChinaTelecom, "199": CarrierChinaTelecom,
+	"1349": CarrierChinaTelecom, "1700": CarrierChinaTelecom, "1701": CarrierChinaTelecom, "1702": CarrierChinaTelecom,
+	"1740": CarrierChinaTelecom, "1741": CarrierChinaTelecom, "1742": CarrierChinaTelecom, "1743": CarrierChinaTelecom,
+	"1744": CarrierChinaTelecom, "1745": CarrierChinaTelecom,
+	// 中国广电
+	"192": CarrierChinaBroadnet,
+}
+
+// MobileCarrier 识别中国大陆手机号码所属运营商，号码格式错误或号段未分配时返回 CarrierUnknown
+func MobileCarrier(phone string) Carrier {
+	if !mobilePattern.MatchString(phone) {
+		return CarrierUnknown
+	}
+	if carrier, ok := carrierPrefixes[phone[:4]]; ok {
+		return carrier
+	}
+	return carrierPrefixes[phone[:3]]
+}
+
+// IsMobile 是否为已分配号段的中国大陆手机号码，carriers 不为空时还要求号码属于其中一个运营商
+func IsMobile(phone string, carriers ...Carrier) bool {
+	carrier := MobileCarrier(phone)
+	if carrier == CarrierUnknown {
+		return false
+	}
+	return len(carriers) == 0 || slices.Contains(carriers, carrier)
+}
+
+var (
+	idCardPattern  = regexp.MustCompile(`^[1-9]\d{16}[\dX]$`)
+	idCardWeights  = []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	idCardCheckSum = "10X98765432"
+)
+
+// IsIDCard 是否为有效的 18 位居民身份证号码（GB 11643），校验出生日期和校验码，末位 x 视为 X
+func IsIDCard(id string) bool {
+	id = strings.ToUpper(id)
+	if !idCardPattern.MatchString(id) {
+		return false
+	}
+
+	birthday, err := time.Parse("20060102", id[6:14])
+	if err != nil || birthday.Year() < 1900 || birthday.After(time.Now()) {
+		return false
+	}
+
+	sum := 0
+	for i, weight := range idCardWeights {
+		sum += int(id[i]-'0') * weight
+	}
+	return id[17] == idCardCheckSum[sum%11]
+}
+
+var (
+	creditCodeChars   = "0123456789ABCDEFGHJKLMNPQRTUWXY"
+	creditCodePattern = regexp.MustCompile(`^[0-9A-HJ-NP-RTUW-Y]{2}\d{6}[0-9A-HJ-NP-RTUW-Y]{10}$`)
+	creditCodeWeights = []int{1, 3, 9, 27, 19, 26, 16, 17, 20, 29, 25, 13, 8, 24, 10, 30, 28}
+)
+
+// IsCreditCode 是否为有效的统一社会信用代码（GB 32100），校验字符集和校验码
+func IsCreditCode(code string) bool {
+	if !creditCodePattern.MatchString(code) {
+		return false
+	}
+
+	sum := 0
+	for i, weight := range creditCodeWeights {
+		sum += strings.IndexByte(creditCodeChars, code[i]) * weight
+	}
+	check := (31 - sum%31) % 31
+	return code[17] == creditCodeChars[check]
+}
+
+func init() {
+	Register(Rule{
+		Tag: "mobile",
+		Func: func(fl validator.FieldLevel) bool {
+			carriers := []Carrier{}
+			for _, carrier := range strings.Fields(fl.Param()) {
+				carriers = append(carriers, Carrier(carrier))
+			}
+			return IsMobile(fl.Field().String(), carriers...)
+		},
+		Description: "中国大陆手机号码，可通过参数限定运营商，如 mobile=china_telecom",
+		Pattern:     mobilePattern.String(),
+		Messages: map[string]string{
+			"zh": "{0}必须是有效的手机号码",
+			"en": "{0} must be a valid mobile phone number",
+		},
+	})
+
+	Register(Rule{
+		Tag: "idcard",
+		Func: func(fl validator.FieldLevel) bool {
+			return IsIDCard(fl.Field().String())
+		},
+		Description: "18 位居民身份证号码，校验出生日期和校验码",
+		Pattern:     `^[1-9]\d{16}[\dXx]$`,
+		Messages: map[string]string{
+			"zh": "{0}必须是有效的身份证号码",
+			"en": "{0} must be a valid resident ID card number",
+		},
+	})
+
+	Register(Rule{
+		Tag: "uscc",
+		Func: func(fl validator.FieldLevel) bool {
+			return IsCreditCode(fl.Field().String())
+		},
+		Description: "统一社会信用代码，校验字符集和校验码",
+		Pattern:     creditCodePattern.String(),
+		Messages: map[string]string{
+			"zh": "{0}必须是有效的统一社会信用代码",
+			"en": "{0} must be a valid unified social credit code",
+		},
+	})
+}
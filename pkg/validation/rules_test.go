@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMobileCarrier(t *testing.T) {
+	assert.Equal(t, CarrierChinaMobile, MobileCarrier("13800138000"))
+	assert.Equal(t, CarrierChinaUnicom, MobileCarrier("18612345678"))
+	assert.Equal(t, CarrierChinaTelecom, MobileCarrier("18912345678"))
+	assert.Equal(t, CarrierChinaTelecom, MobileCarrier("13491234567"))
+	assert.Equal(t, CarrierChinaBroadnet, MobileCarrier("19212345678"))
+	assert.Equal(t, CarrierUnknown, MobileCarrier("12012345678"))
+	assert.Equal(t, CarrierUnknown, MobileCarrier("1381234567"))
+
+	assert.True(t, IsMobile("18912345678", CarrierChinaTelecom))
+	assert.False(t, IsMobile("13800138000", CarrierChinaTelecom, CarrierChinaUnicom))
+}
+
+func TestIsIDCard(t *testing.T) {
+	assert.True(t, IsIDCard("11010519491231002X"))
+	assert.True(t, IsIDCard("11010519491231002x"))
+	assert.False(t, IsIDCard("110105194912310021"))
+	assert.False(t, IsIDCard("110105194913310028"))
+	assert.False(t, IsIDCard("11010519491231002"))
+}
+
+func TestIsCreditCode(t *testing.T) {
+	assert.True(t, IsCreditCode("91350100M000100Y43"))
+	assert.False(t, IsCreditCode("91350100M000100Y44"))
+	assert.False(t, IsCreditCode("91350100M000100I43"))
+}
+
+func TestRegister(t *testing.T) {
+	_, ok := Lookup("mobile")
+	assert.True(t, ok)
+	assert.Panics(t, func() {
+		Register(Rule{Tag: "mobile", Func: func(fl validator.FieldLevel) bool { return true }, Messages: map[string]string{"zh": "{0}"}})
+	})
+	assert.Panics(t, func() {
+		Register(Rule{Tag: "no_message", Func: func(fl validator.FieldLevel) bool { return true }})
+	})
+}
@@ -0,0 +1,71 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Rule 自定义校验规则
+//
+// 在 init 中通过 Register 注册，http.NewValidator 创建校验器时统一注册到 validator，
+// 因此任何包都可以注册规则，只要在创建 Server 之前完成即可。
+type Rule struct {
+	// validate 标签中使用的规则名
+	Tag  string
+	Func validator.Func
+	// 规则说明，用于启动时的路由表和 OpenAPI 文档
+	Description string
+	// OpenAPI 文档中字段的 pattern 和 format，可为空
+	Pattern string
+	Format  string
+	// 各语言的提示，key 为语言标签（zh、en），{0} 为字段名，{1} 为规则参数
+	Messages map[string]string
+}
+
+// 默认语言，必须提供该语言的提示
+const DefaultLanguage = "zh"
+
+var (
+	mu    sync.RWMutex
+	rules = map[string]*Rule{}
+)
+
+// Register 注册自定义校验规则，规则名重复或缺少默认语言提示时 panic
+func Register(rule Rule) {
+	if rule.Tag == "" || rule.Func == nil {
+		panic("validation rule must have tag and func")
+	}
+	if _, ok := rule.Messages[DefaultLanguage]; !ok {
+		panic(fmt.Sprintf("validation rule %s has no %s message", rule.Tag, DefaultLanguage))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := rules[rule.Tag]; ok {
+		panic(fmt.Sprintf("duplicate validation rule %s", rule.Tag))
+	}
+	rules[rule.Tag] = &rule
+}
+
+// Lookup 查找自定义校验规则
+func Lookup(tag string) (*Rule, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	rule, ok := rules[tag]
+	return rule, ok
+}
+
+// Rules 已注册的全部自定义校验规则，按规则名排序
+func Rules() []*Rule {
+	mu.RLock()
+	defer mu.RUnlock()
+	list := make([]*Rule, 0, len(rules))
+	for _, rule := range rules {
+		list = append(list, rule)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Tag < list[j].Tag })
+	return list
+}
@@ -3,39 +3,223 @@ package server
 import (
 	"context"
 	"log/slog"
-	"net"
 	"os"
 	"os/signal"
 	"syscall"
 	"telecommunications_repair_hub/config"
 	"telecommunications_repair_hub/http"
+	"telecommunications_repair_hub/pkg/auth"
+	"telecommunications_repair_hub/pkg/db"
+	"telecommunications_repair_hub/pkg/lifecycle"
 	"telecommunications_repair_hub/pkg/logger"
+	"time"
 )
 
-func NewTelecommunicationsServer() {
-	cfg := config.InitConfig()
-	logger.NewLogger(cfg.App.LogLevel,
-		cfg.App.LogOutput,
-		cfg.App.Logger.Rotation,
-		cfg.App.Logger.RotationTime,
-		cfg.App.Logger.RotationSize,
-		cfg.App.Logger.RotationCount,
-	).Init()
+// 进程退出码
+const (
+	// 收到退出信号后正常关闭
+	ExitOK = 0
+	// 服务启动失败或运行中异常退出
+	ExitStartFailed = 1
+	// 关闭超时或关闭钩子执行失败
+	ExitShutdownFailed = 2
+	// 关闭过程中再次收到退出信号，强制退出
+	ExitForced = 130
+)
+
+// 未配置 app.shutdown 时的默认值
+const (
+	defaultDrainPeriod     = 5 * time.Second
+	defaultShutdownTimeout = 30 * time.Second
+)
 
-	TelecommunicationsServer := http.NewHttpServer(cfg)
+// 启动超时时间，超时视为启动失败
+const startTimeout = 30 * time.Second
+
+// Options 启动选项
+type Options struct {
+	Config config.Options
+	// 启动前执行未执行的数据库迁移，多个实例同时启动时由 advisory lock 保证只执行一次
+	Migrate bool
+}
 
-	ctx, cancel := context.WithCancel(context.Background())
+// TelecommunicationsServer 服务的全部子系统，由 lifecycle.Container 按依赖顺序启动和停止
+type TelecommunicationsServer struct {
+	container *lifecycle.Container
+	options   Options
 
-	signalChan := make(chan os.Signal, 1)
+	config   *config.Config
+	reloader *config.Reloader
+	logger   *logger.Logger
+	db       *db.DB
+	http     *http.HttpServer
+}
+
+// NewTelecommunicationsServer 启动服务并阻塞到服务退出，返回进程退出码
+//
+// 子系统启动顺序：config -> logger -> database -> hooks -> http -> reloader，任一子系统启动失败时停止已启动的子系统并退出。
+// 收到 SIGINT/SIGTERM 或子系统运行中异常退出后按以下顺序关闭：
+// readiness 置为 false -> 等待 drainPeriod -> 停止 HTTP 服务并等待处理中的请求完成 ->
+// 执行 lifecycle 关闭钩子 -> 关闭数据库连接 -> 关闭日志文件。
+// 关闭过程中再次收到退出信号时立即退出。
+func NewTelecommunicationsServer(opts Options) int {
+	s := &TelecommunicationsServer{container: lifecycle.NewContainer(), options: opts}
+	if err := s.register(); err != nil {
+		slog.Error("TelecommunicationsServer register components failed", "error", err)
+		return ExitStartFailed
+	}
+
+	signalChan := make(chan os.Signal, 2)
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+
+	startCtx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	err := s.container.Start(startCtx)
+	cancel()
+	if err != nil {
+		slog.Error("TelecommunicationsServer start failed", "error", err)
+		return ExitStartFailed
+	}
+
+	exitCode := ExitOK
+	select {
+	case sig := <-signalChan:
+		slog.Info("TelecommunicationsServer received signal", "signal", sig.String())
+	case err := <-s.container.Failed():
+		slog.Error("TelecommunicationsServer exited unexpectedly", "error", err)
+		exitCode = ExitStartFailed
+	}
 
-	address := net.JoinHostPort(cfg.App.Host, cfg.App.Port)
 	go func() {
-		slog.Info("TelecommunicationsServer is starting", "address", address)
-		TelecommunicationsServer.Start(ctx)
+		sig := <-signalChan
+		slog.Warn("TelecommunicationsServer forced to exit", "signal", sig.String())
+		s.logger.Close()
+		os.Exit(ExitForced)
 	}()
 
-	<-signalChan
+	if err := s.shutdown(); err != nil && exitCode == ExitOK {
+		exitCode = ExitShutdownFailed
+	}
+	// 日志组件已关闭，此后的日志只输出到标准输出
+	slog.Info("TelecommunicationsServer stopped", "exitCode", exitCode)
+	return exitCode
+}
 
-	cancel()
+// register 注册全部子系统，后台任务等新的子系统在这里注册并声明依赖
+func (s *TelecommunicationsServer) register() error {
+	components := []lifecycle.Component{
+		{
+			Name: "config",
+			Start: func(ctx context.Context) (err error) {
+				s.config, err = config.Load(s.options.Config)
+				if err != nil {
+					return err
+				}
+				auth.SetSecret(s.config.App.JWT.Secret)
+				s.reloader = config.NewReloader(s.options.Config, s.config)
+				return nil
+			},
+		},
+		{
+			Name:      "logger",
+			DependsOn: []string{"config"},
+			Start: func(ctx context.Context) error {
+				s.logger = logger.NewLogger(s.config.App.LogLevel,
+					s.config.App.LogOutput,
+					s.config.App.Logger.Rotation,
+					s.config.App.Logger.RotationTime,
+					s.config.App.Logger.RotationSize,
+					s.config.App.Logger.RotationCount,
+				)
+				s.logger.Init()
+				return nil
+			},
+			Stop: func(ctx context.Context) error {
+				return s.logger.Close()
+			},
+		},
+		{
+			Name:      "database",
+			DependsOn: []string{"config", "logger"},
+			Start: func(ctx context.Context) (err error) {
+				s.db, err = db.New(s.config)
+				if err != nil || !s.options.Migrate {
+					return err
+				}
+				_, err = s.db.MigrateUp(ctx, db.MigrateOptions{})
+				return err
+			},
+			Stop: func(ctx context.Context) error {
+				return s.db.Close()
+			},
+		},
+		{
+			// 通过 lifecycle.OnShutdown 注册的钩子在 HTTP 服务停止后、数据库关闭前执行
+			Name:      "hooks",
+			DependsOn: []string{"database"},
+			Stop:      lifecycle.Shutdown,
+		},
+		{
+			Name:      "http",
+			DependsOn: []string{"config", "logger", "database", "hooks"},
+			Start: func(ctx context.Context) error {
+				s.http = http.NewHttpServer(s.config, s.db)
+				if err := s.http.Start(ctx); err != nil {
+					return err
+				}
+				go func() {
+					if err, ok := <-s.http.Err(); ok {
+						s.container.Fail("http", err)
+					}
+				}()
+				return nil
+			},
+			Stop: func(ctx context.Context) error {
+				return s.http.Shutdown(ctx)
+			},
+		},
+		{
+			// 配置文件修改后热更新日志级别、限速、CORS、SQL 日志和功能开关
+			Name:      "reloader",
+			DependsOn: []string{"config", "logger", "database", "http"},
+			Start: func(ctx context.Context) error {
+				s.reloader.Subscribe("logger", func(cfg *config.Config) {
+					s.logger.SetLevel(cfg.App.LogLevel)
+				})
+				s.reloader.Subscribe("database", s.db.ApplyConfig)
+				s.reloader.Subscribe("http", s.http.ApplyConfig)
+				return s.reloader.Watch()
+			},
+			Stop: func(ctx context.Context) error {
+				return s.reloader.Close()
+			},
+		},
+	}
+
+	for _, component := range components {
+		if err := s.container.Register(component); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TelecommunicationsServer) shutdown() error {
+	drainPeriod, timeout := defaultDrainPeriod, defaultShutdownTimeout
+	if shutdownConfig := s.config.GetShutdownConfig(); shutdownConfig != nil {
+		drainPeriod = shutdownConfig.DrainPeriod
+		if shutdownConfig.Timeout > 0 {
+			timeout = shutdownConfig.Timeout
+		}
+	}
+
+	lifecycle.BeginShutdown()
+	if drainPeriod > 0 {
+		slog.Info("TelecommunicationsServer draining", "drainPeriod", drainPeriod)
+		time.Sleep(drainPeriod)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return s.container.Stop(ctx)
 }